@@ -0,0 +1,155 @@
+package gxschema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//MarshalDataXML generate XML data of document: element order and naming follow item definitions,
+//array item repeats its element for every value and DxFile value becomes filename and filepath sub-elements,
+//so result can be validated by ValidateDataFromXML. Data is validated first, undeclared key is not generated
+func MarshalDataXML(doc *DxDoc, data map[string]interface{}) (string, error) {
+	if err := doc.ValidateData(data); err != nil {
+		return "", err
+	}
+
+	result := "<?xml version=\"1.0\"?>\n<" + doc.Name + ">"
+
+	for _, item := range doc.Items {
+		element, err := marshalItemXML(item, data, 1)
+		if err != nil {
+			return "", err
+		}
+
+		result += element
+	}
+
+	return result + "\n</" + doc.Name + ">", nil
+}
+
+//marshalItemXML generate element of item value in data, one element per array value,
+//missing or nil value generates nothing; empty value of required array item can't be represented in XML
+func marshalItemXML(item DxItem, data map[string]interface{}, indentLevel int) (string, error) {
+	rawValue, ok := data[item.GetName()]
+	if !ok || rawValue == nil {
+		return "", nil
+	}
+
+	values := []interface{}{rawValue}
+
+	if list := reflect.ValueOf(rawValue); item.IsValueArray() && list.Kind() == reflect.Slice {
+		values = make([]interface{}, list.Len())
+		for index := range values {
+			values[index] = list.Index(index).Interface()
+		}
+
+		if len(values) == 0 && !item.IsValueOptional() {
+			return "", fmt.Errorf("required array item '%s' has no value which XML can't represent", item.GetName())
+		}
+	}
+
+	var result string
+
+	for _, value := range values {
+		element, err := marshalValueXML(item.GetName(), dereferenceItem(item), value, indentLevel)
+		if err != nil {
+			return "", err
+		}
+
+		result += "\n" + element
+	}
+
+	return result, nil
+}
+
+//marshalValueXML generate single element of value, item is nil for value which is not declared by any item
+func marshalValueXML(name string, item DxItem, value interface{}, indentLevel int) (string, error) {
+	indent := strings.Repeat("\t", indentLevel)
+
+	var children string
+
+	switch tmp := item.(type) {
+	case DxSection:
+		section, _ := value.(map[string]interface{})
+		for _, subItem := range tmp.Items {
+			element, err := marshalItemXML(subItem, section, indentLevel+1)
+			if err != nil {
+				return "", err
+			}
+
+			children += element
+		}
+	case DxFile:
+		for _, key := range []string{"filename", "filepath"} {
+			element, _ := marshalValueXML(key, nil, mapValue(value, key), indentLevel+1)
+			children += "\n" + element
+		}
+	default:
+		//map value of custom item type, keys are sorted so result is stable
+		if mapping := reflect.ValueOf(value); mapping.Kind() == reflect.Map {
+			var keys []string
+			for _, key := range mapping.MapKeys() {
+				keys = append(keys, fmt.Sprint(key.Interface()))
+			}
+
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				//value which is not declared by any item has no array constraint, hence no error
+				element, _ := marshalValueXML(key, nil, mapValue(value, key), indentLevel+1)
+				children += "\n" + element
+			}
+		} else {
+			return indent + "<" + name + ">" + escapeXMLAttribute(formatXMLValue(item, value)) + "</" + name + ">", nil
+		}
+	}
+
+	if children == "" {
+		return indent + "<" + name + "></" + name + ">", nil
+	}
+
+	return indent + "<" + name + ">" + children + "\n" + indent + "</" + name + ">", nil
+}
+
+//mapValue get value of key from map with string key, nil if not found
+func mapValue(value interface{}, key string) interface{} {
+	mapping := reflect.ValueOf(value)
+	if mapping.Kind() != reflect.Map || mapping.Type().Key().Kind() != reflect.String {
+		return nil
+	}
+
+	result := mapping.MapIndex(reflect.ValueOf(key).Convert(mapping.Type().Key()))
+	if !result.IsValid() {
+		return nil
+	}
+
+	return result.Interface()
+}
+
+//formatXMLValue format scalar value as element text, float64 is written without exponent and time.Time is written
+//in layout of temporal item so it can be parsed back
+func formatXMLValue(item DxItem, value interface{}) string {
+	switch tmp := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(tmp, 'f', -1, 64)
+	case time.Time:
+		switch temporalItem := item.(type) {
+		case DxDate:
+			return temporalItem.temporal().formatValue(tmp)
+		case DxTime:
+			return temporalItem.temporal().formatValue(tmp)
+		case DxDateTime:
+			return temporalItem.temporal().formatValue(tmp)
+		}
+
+		return tmp.Format(time.RFC3339Nano)
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,142 @@
+package gxschema
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarshalDataXML(t *testing.T) {
+	docSchema, schemaErr := ParseSchemaFromXML(`<dxdoc name="invoice" revision="1" id="1">
+	<dxstr name="docNo"></dxstr>
+	<dxdate name="issueDate"></dxdate>
+	<dxstr name="remark" isOptional="true"></dxstr>
+	<dxbool name="isPaid"></dxbool>
+	<dxstr name="tags" isArray="true"></dxstr>
+	<dxsection name="items" isArray="true">
+		<dxstr name="sku"></dxstr>
+		<dxint name="qty"></dxint>
+		<dxdecimal name="price" precision="2"></dxdecimal>
+	</dxsection>
+	<dxfile name="attachment"></dxfile>
+</dxdoc>`)
+	if schemaErr != nil {
+		t.Error(schemaErr)
+		return
+	}
+
+	//key order and undeclared key in JSON don't affect generated XML
+	dataJSON := `{
+		"note": "not declared",
+		"items": [{"qty": 2, "sku": "A&1", "price": 12.5}, {"sku": "B2", "qty": 1, "price": 3}],
+		"attachment": {"filepath": "/tmp/a.pdf", "filename": "a.pdf"},
+		"tags": ["urgent"],
+		"isPaid": false,
+		"issueDate": "2020-01-31",
+		"docNo": "00123"
+	}`
+
+	if err := ValidateDataFromJSON(dataJSON, docSchema); err != nil {
+		t.Error(err)
+		return
+	}
+
+	data, parseErr := parseDataFromJSON(dataJSON)
+	if parseErr != nil {
+		t.Error(parseErr)
+		return
+	}
+
+	xmlStr, err := MarshalDataXML(docSchema, data)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := `<?xml version="1.0"?>
+<invoice>
+	<docNo>00123</docNo>
+	<issueDate>2020-01-31</issueDate>
+	<isPaid>false</isPaid>
+	<tags>urgent</tags>
+	<items>
+		<sku>A&amp;1</sku>
+		<qty>2</qty>
+		<price>12.5</price>
+	</items>
+	<items>
+		<sku>B2</sku>
+		<qty>1</qty>
+		<price>3</price>
+	</items>
+	<attachment>
+		<filename>a.pdf</filename>
+		<filepath>/tmp/a.pdf</filepath>
+	</attachment>
+</invoice>`
+
+	if strings.Compare(xmlStr, expected) != 0 {
+		t.Errorf("XML output not tally with [output]: \n%s\n\n[expected]:\n%s", xmlStr, expected)
+	}
+
+	if err := ValidateDataFromXML(xmlStr, docSchema); err != nil {
+		t.Errorf("Expect generated XML is valid but get: %s", err.Error())
+	}
+
+	delete(data, "docNo")
+
+	if _, err := MarshalDataXML(docSchema, data); err == nil {
+		t.Error("Expect error occured due to docNo is missing")
+	}
+
+	//empty required array has no element, so XML would not be valid
+	data["docNo"] = "00123"
+	data["tags"] = []interface{}{}
+
+	if xmlStr, err := MarshalDataXML(docSchema, data); err == nil {
+		t.Errorf("Expect error occured due to tags is empty but get:\n%s", xmlStr)
+	}
+}
+
+func TestMarshalDataXML_temporal(t *testing.T) {
+	docSchema, schemaErr := ParseSchemaFromXML(`<dxdoc name="shift" revision="1" id="1">
+	<dxdate name="workDate"></dxdate>
+	<dxdate name="payDate" format="02/01/2006"></dxdate>
+	<dxtime name="startTime"></dxtime>
+	<dxdatetime name="clockIn" isArray="true"></dxdatetime>
+</dxdoc>`)
+	if schemaErr != nil {
+		t.Error(schemaErr)
+		return
+	}
+
+	clockIn := time.Date(2020, 1, 31, 8, 30, 0, 0, time.FixedZone("", 8*60*60))
+
+	data := map[string]interface{}{
+		"workDate":  time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC),
+		"payDate":   time.Date(2020, 2, 28, 0, 0, 0, 0, time.UTC),
+		"startTime": time.Date(0, 1, 1, 8, 0, 0, 0, time.UTC),
+		"clockIn":   []time.Time{clockIn},
+	}
+
+	xmlStr, err := MarshalDataXML(docSchema, data)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, element := range []string{
+		"<workDate>2020-01-31</workDate>",
+		"<payDate>28/02/2020</payDate>",
+		"<startTime>08:00:00Z</startTime>",
+		"<clockIn>2020-01-31T08:30:00+08:00</clockIn>",
+	} {
+		if !strings.Contains(xmlStr, element) {
+			t.Errorf("Expect XML output contains %s but get:\n%s", element, xmlStr)
+		}
+	}
+
+	if err := ValidateDataFromXML(xmlStr, docSchema); err != nil {
+		t.Errorf("Expect generated XML is valid but get: %s", err.Error())
+	}
+}
@@ -0,0 +1,638 @@
+package gxschema
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+//MigrationStep single data transformation of a migration; path is '/' separated item names (e.g. customer/name),
+//step is applied to every element when path goes through array
+type MigrationStep interface {
+	Apply(data map[string]interface{}) error //Apply transform data in place
+	XML(indentLevel int) string              //XML generate into XML format
+}
+
+//RenameStep rename key at Path into To, key stays in the same section
+type RenameStep struct {
+	Path string
+	To   string
+}
+
+//MoveStep move value at From into To, sections of To are created when not exists
+type MoveStep struct {
+	From string
+	To   string
+}
+
+//SplitStep split string value at From by Separator into sibling keys To, From is removed;
+//last key gets remaining text when value has more parts than keys
+type SplitStep struct {
+	From      string
+	To        []string
+	Separator string
+}
+
+//DefaultStep set Value at Path when key is not exists or is null, section which is not exists is skipped
+type DefaultStep struct {
+	Path  string
+	Value interface{}
+}
+
+//ConvertStep convert value at Path into data type accepted by item tag To (dxint, dxdecimal, dxstr or dxbool)
+type ConvertStep struct {
+	Path string
+	To   string
+}
+
+//Migration steps which upgrade data of document ID from revision From to revision To
+type Migration struct {
+	ID    string
+	From  int
+	To    int
+	Steps []MigrationStep
+}
+
+//XML generate migration into XML format
+func (migration Migration) XML() (string, error) {
+	result := fmt.Sprintf("<?xml version=\"1.0\"?>\n<dxmigration id=\"%s\" from=\"%d\" to=\"%d\">",
+		escapeXMLAttribute(migration.ID), migration.From, migration.To)
+
+	for _, step := range migration.Steps {
+		result += "\n" + step.XML(1)
+	}
+
+	return result + "\n</dxmigration>", nil
+}
+
+//Apply run every step on data in order
+func (migration Migration) Apply(data map[string]interface{}) error {
+	for index, step := range migration.Steps {
+		if err := step.Apply(data); err != nil {
+			return fmt.Errorf("migration %s@%d -> %d step %d failed: %s",
+				migration.ID, migration.From, migration.To, index, err.Error())
+		}
+	}
+
+	return nil
+}
+
+//Migrator upgrade data of a document through chained migrations, result is validated against target revision
+type Migrator struct {
+	id         string
+	docs       []*DxDoc
+	migrations []*Migration
+}
+
+//NewMigrator create migrator of document ID
+func NewMigrator(id string) *Migrator {
+	return &Migrator{id: id}
+}
+
+//AddSchema register document revision which migrated data is validated against
+func (migrator *Migrator) AddSchema(doc *DxDoc) error {
+	if doc.ID != migrator.id {
+		return fmt.Errorf("schema ID %s is not %s", doc.ID, migrator.id)
+	}
+
+	for _, tmp := range migrator.docs {
+		if tmp.Revision == doc.Revision {
+			return fmt.Errorf("schema %s revision %d is already added", doc.ID, doc.Revision)
+		}
+	}
+
+	migrator.docs = append(migrator.docs, doc)
+
+	return nil
+}
+
+//AddMigration register migration, only one migration can start from each revision
+func (migrator *Migrator) AddMigration(migration *Migration) error {
+	if migration.ID != migrator.id {
+		return fmt.Errorf("migration ID %s is not %s", migration.ID, migrator.id)
+	}
+
+	if migration.To <= migration.From {
+		return fmt.Errorf("migration of %s must upgrade revision but get %d -> %d",
+			migration.ID, migration.From, migration.To)
+	}
+
+	for _, tmp := range migrator.migrations {
+		if tmp.From == migration.From {
+			return fmt.Errorf("migration of %s from revision %d is already added", migration.ID, migration.From)
+		}
+	}
+
+	migrator.migrations = append(migrator.migrations, migration)
+
+	return nil
+}
+
+//Migrate upgrade data from revision fromRev to toRev by chaining migrations, input data is not modified;
+//*ValidationReport is returned when migrated data is invalid under revision toRev
+func (migrator *Migrator) Migrate(data map[string]interface{}, fromRev int, toRev int) (map[string]interface{}, error) {
+	var target *DxDoc
+	for _, doc := range migrator.docs {
+		if doc.Revision == toRev {
+			target = doc
+		}
+	}
+
+	if target == nil {
+		return nil, fmt.Errorf("schema %s revision %d is not added", migrator.id, toRev)
+	}
+
+	if toRev < fromRev {
+		return nil, fmt.Errorf("downgrade %s from revision %d to %d is not supported", migrator.id, fromRev, toRev)
+	}
+
+	result := copyMigrationValue(data).(map[string]interface{})
+
+	for revision := fromRev; revision < toRev; {
+		migration := migrator.findMigration(revision)
+		if migration == nil || migration.To > toRev {
+			return nil, fmt.Errorf("no migration of %s from revision %d towards %d", migrator.id, revision, toRev)
+		}
+
+		if err := migration.Apply(result); err != nil {
+			return nil, err
+		}
+
+		revision = migration.To
+	}
+
+	if report := target.ValidateAll(result); !report.IsValid() {
+		return nil, report
+	}
+
+	return result, nil
+}
+
+func (migrator *Migrator) findMigration(from int) *Migration {
+	for _, migration := range migrator.migrations {
+		if migration.From == from {
+			return migration
+		}
+	}
+
+	return nil
+}
+
+//ParseMigrationFromXML parse migration from XML string, e.g.
+//<dxmigration id="..." from="3" to="4"><rename path="remark" to="note"></rename></dxmigration>
+func ParseMigrationFromXML(rawXML string) (*Migration, error) {
+	var n XMLNode
+
+	if err := xml.Unmarshal([]byte(rawXML), &n); err != nil {
+		return nil, err
+	}
+
+	if n.XMLName.Local != "dxmigration" {
+		return nil, fmt.Errorf("expect tag name is dxmigration but get %s instead", n.XMLName.Local)
+	}
+
+	attributes := migrationAttributes(&n)
+
+	migration := &Migration{ID: attributes["id"]}
+	if migration.ID == "" {
+		return nil, fmt.Errorf("<dxmigration> tag missing attribute 'id'")
+	}
+
+	var err error
+
+	if migration.From, err = strconv.Atoi(attributes["from"]); err != nil {
+		return nil, fmt.Errorf("<dxmigration> tag attribute 'from' is not integer: %s", attributes["from"])
+	}
+
+	if migration.To, err = strconv.Atoi(attributes["to"]); err != nil {
+		return nil, fmt.Errorf("<dxmigration> tag attribute 'to' is not integer: %s", attributes["to"])
+	}
+
+	for index := range n.Nodes {
+		step, stepErr := walkMigrationStep(&n.Nodes[index])
+		if stepErr != nil {
+			return nil, fmt.Errorf("failed to parse %s at path dxmigration>%s(%d): %s",
+				n.Nodes[index].XMLName.Local, n.Nodes[index].XMLName.Local, index, stepErr.Error())
+		}
+
+		migration.Steps = append(migration.Steps, step)
+	}
+
+	return migration, nil
+}
+
+func migrationAttributes(node *XMLNode) map[string]string {
+	attributes := make(map[string]string)
+	for _, attribute := range node.Attributes {
+		attributes[attribute.Name.Local] = attribute.Value
+	}
+
+	return attributes
+}
+
+func walkMigrationStep(node *XMLNode) (MigrationStep, error) {
+	attributes := migrationAttributes(node)
+
+	required := map[string][]string{
+		"rename":  {"path", "to"},
+		"move":    {"from", "to"},
+		"split":   {"from", "to", "separator"},
+		"default": {"path", "value"},
+		"convert": {"path", "to"},
+	}
+
+	names, ok := required[node.XMLName.Local]
+	if !ok {
+		return nil, fmt.Errorf("unknown migration step")
+	}
+
+	for _, name := range names {
+		if _, ok := attributes[name]; !ok {
+			return nil, fmt.Errorf("missing '%s' attribute", name)
+		}
+	}
+
+	switch node.XMLName.Local {
+	case "rename":
+		return RenameStep{Path: attributes["path"], To: attributes["to"]}, nil
+	case "move":
+		return MoveStep{From: attributes["from"], To: attributes["to"]}, nil
+	case "split":
+		step := SplitStep{From: attributes["from"], To: strings.Split(attributes["to"], ","),
+			Separator: attributes["separator"]}
+		if err := step.validate(); err != nil {
+			return nil, err
+		}
+
+		return step, nil
+	case "default":
+		valueType := attributes["type"]
+		if valueType == "" {
+			valueType = "dxstr"
+		}
+
+		value, err := convertMigrationValue(attributes["value"], valueType)
+		if err != nil {
+			return nil, err
+		}
+
+		return DefaultStep{Path: attributes["path"], Value: value}, nil
+	}
+
+	if !isMigrationTypeSupported(attributes["to"]) {
+		return nil, fmt.Errorf("can't convert value into %s", attributes["to"])
+	}
+
+	return ConvertStep{Path: attributes["path"], To: attributes["to"]}, nil
+}
+
+//Apply rename key
+func (step RenameStep) Apply(data map[string]interface{}) error {
+	return forEachMigrationKey(data, step.Path, func(parent map[string]interface{}, key string) error {
+		if value, ok := parent[key]; ok {
+			delete(parent, key)
+			parent[step.To] = value
+		}
+
+		return nil
+	})
+}
+
+//XML generate XML
+func (step RenameStep) XML(indentLevel int) string {
+	return strings.Repeat("\t", indentLevel) + "<rename path=\"" + escapeXMLAttribute(step.Path) +
+		"\" to=\"" + escapeXMLAttribute(step.To) + "\"></rename>"
+}
+
+//Apply move value, path segments shared by From and To (e.g. array section) are traversed once
+func (step MoveStep) Apply(data map[string]interface{}) error {
+	from := strings.Split(step.From, "/")
+	to := strings.Split(step.To, "/")
+
+	common := 0
+	for common < len(from)-1 && common < len(to)-1 && from[common] == to[common] {
+		common++
+	}
+
+	move := func(container map[string]interface{}) error {
+		var value interface{}
+		found := false
+
+		err := forEachMigrationKey(container, strings.Join(from[common:], "/"),
+			func(parent map[string]interface{}, key string) error {
+				if tmp, ok := parent[key]; ok {
+					if found {
+						return fmt.Errorf("%s refers to more than one value", step.From)
+					}
+
+					value, found = tmp, true
+					delete(parent, key)
+				}
+
+				return nil
+			})
+		if err != nil || !found {
+			return err
+		}
+
+		parent := container
+		for _, segment := range to[common : len(to)-1] {
+			child, ok := parent[segment].(map[string]interface{})
+			if !ok {
+				if parent[segment] != nil {
+					return fmt.Errorf("%s is not a section", segment)
+				}
+
+				child = make(map[string]interface{})
+				parent[segment] = child
+			}
+
+			parent = child
+		}
+
+		parent[to[len(to)-1]] = value
+
+		return nil
+	}
+
+	if common == 0 {
+		return move(data)
+	}
+
+	return forEachMigrationKey(data, strings.Join(from[:common], "/"),
+		func(parent map[string]interface{}, key string) error {
+			return forEachMigrationSection(parent[key], move)
+		})
+}
+
+//XML generate XML
+func (step MoveStep) XML(indentLevel int) string {
+	return strings.Repeat("\t", indentLevel) + "<move from=\"" + escapeXMLAttribute(step.From) +
+		"\" to=\"" + escapeXMLAttribute(step.To) + "\"></move>"
+}
+
+//validate reject step which loses value: empty Separator or no key (or empty key) to hold split parts
+func (step SplitStep) validate() error {
+	if step.Separator == "" {
+		return fmt.Errorf("separator of split can't be empty")
+	}
+
+	if len(step.To) == 0 {
+		return fmt.Errorf("split requires at least one 'to' key")
+	}
+
+	for _, key := range step.To {
+		if key == "" {
+			return fmt.Errorf("'to' key of split can't be empty")
+		}
+	}
+
+	return nil
+}
+
+//Apply split string value
+func (step SplitStep) Apply(data map[string]interface{}) error {
+	if err := step.validate(); err != nil {
+		return err
+	}
+
+	return forEachMigrationKey(data, step.From, func(parent map[string]interface{}, key string) error {
+		rawValue, ok := parent[key]
+		if !ok || rawValue == nil {
+			return nil
+		}
+
+		value, strOK := rawValue.(string)
+		if !strOK {
+			return fmt.Errorf("%s is not string", step.From)
+		}
+
+		delete(parent, key)
+
+		for index, part := range strings.SplitN(value, step.Separator, len(step.To)) {
+			parent[step.To[index]] = part
+		}
+
+		return nil
+	})
+}
+
+//XML generate XML
+func (step SplitStep) XML(indentLevel int) string {
+	return strings.Repeat("\t", indentLevel) + "<split from=\"" + escapeXMLAttribute(step.From) +
+		"\" to=\"" + escapeXMLAttribute(strings.Join(step.To, ",")) +
+		"\" separator=\"" + escapeXMLAttribute(step.Separator) + "\"></split>"
+}
+
+//Apply fill default value
+func (step DefaultStep) Apply(data map[string]interface{}) error {
+	return forEachMigrationKey(data, step.Path, func(parent map[string]interface{}, key string) error {
+		if parent[key] == nil {
+			parent[key] = step.Value
+		}
+
+		return nil
+	})
+}
+
+//XML generate XML
+func (step DefaultStep) XML(indentLevel int) string {
+	var valueType string
+
+	switch value := step.Value.(type) {
+	case int:
+		valueType = "dxint"
+	case decimal.Decimal:
+		valueType = "dxdecimal"
+	case float64:
+		valueType = "dxdecimal"
+		step.Value = decimal.NewFromFloat(value)
+	case bool:
+		valueType = "dxbool"
+	}
+
+	result := strings.Repeat("\t", indentLevel) + "<default path=\"" + escapeXMLAttribute(step.Path) +
+		"\" value=\"" + escapeXMLAttribute(fmt.Sprint(step.Value)) + "\""
+
+	if valueType != "" {
+		result += " type=\"" + valueType + "\""
+	}
+
+	return result + "></default>"
+}
+
+//Apply convert value, every element is converted when value is an array
+func (step ConvertStep) Apply(data map[string]interface{}) error {
+	return forEachMigrationKey(data, step.Path, func(parent map[string]interface{}, key string) error {
+		rawValue, ok := parent[key]
+		if !ok || rawValue == nil {
+			return nil
+		}
+
+		if list := reflect.ValueOf(rawValue); list.Kind() == reflect.Slice {
+			arr := make([]interface{}, list.Len())
+			for index := range arr {
+				value, err := convertMigrationValue(list.Index(index).Interface(), step.To)
+				if err != nil {
+					return fmt.Errorf("%s[%d] %s", step.Path, index, err.Error())
+				}
+
+				arr[index] = value
+			}
+
+			parent[key] = arr
+
+			return nil
+		}
+
+		value, err := convertMigrationValue(rawValue, step.To)
+		if err != nil {
+			return fmt.Errorf("%s %s", step.Path, err.Error())
+		}
+
+		parent[key] = value
+
+		return nil
+	})
+}
+
+//XML generate XML
+func (step ConvertStep) XML(indentLevel int) string {
+	return strings.Repeat("\t", indentLevel) + "<convert path=\"" + escapeXMLAttribute(step.Path) +
+		"\" to=\"" + escapeXMLAttribute(step.To) + "\"></convert>"
+}
+
+func isMigrationTypeSupported(itemType string) bool {
+	return isStringInSlice(itemType, []string{"dxint", "dxdecimal", "dxstr", "dxbool"})
+}
+
+//convertMigrationValue convert value into GO type accepted by item tag
+func convertMigrationValue(value interface{}, itemType string) (interface{}, error) {
+	if !isMigrationTypeSupported(itemType) {
+		return nil, fmt.Errorf("can't convert value into %s", itemType)
+	}
+
+	var str string
+
+	switch tmp := value.(type) {
+	case string:
+		str = tmp
+	case int:
+		str = strconv.Itoa(tmp)
+	case float64:
+		if math.IsNaN(tmp) || math.IsInf(tmp, 0) {
+			return nil, fmt.Errorf("can't convert %v into %s", value, itemType)
+		}
+
+		str = decimal.NewFromFloat(tmp).String()
+	case decimal.Decimal:
+		str = tmp.String()
+	case bool:
+		str = strconv.FormatBool(tmp)
+	default:
+		return nil, fmt.Errorf("can't convert %T into %s", value, itemType)
+	}
+
+	switch itemType {
+	case "dxint":
+		tmp, err := decimal.NewFromString(str)
+		if err != nil || !tmp.Equal(tmp.Truncate(0)) {
+			return nil, fmt.Errorf("can't convert %v into %s", value, itemType)
+		}
+
+		if tmp.LessThan(decimal.NewFromInt(math.MinInt)) || tmp.GreaterThan(decimal.NewFromInt(math.MaxInt)) {
+			return nil, fmt.Errorf("can't convert %v into %s, value is out of range", value, itemType)
+		}
+
+		return int(tmp.IntPart()), nil
+	case "dxdecimal":
+		tmp, err := decimal.NewFromString(str)
+		if err != nil {
+			return nil, fmt.Errorf("can't convert %v into %s", value, itemType)
+		}
+
+		return tmp, nil
+	case "dxbool":
+		tmp, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, fmt.Errorf("can't convert %v into %s", value, itemType)
+		}
+
+		return tmp, nil
+	}
+
+	return str, nil
+}
+
+//forEachMigrationKey call fn with container map and key of path, every element is visited when path goes through
+//array; missing section is skipped
+func forEachMigrationKey(data map[string]interface{}, path string,
+	fn func(parent map[string]interface{}, key string) error) error {
+	segments := strings.Split(path, "/")
+
+	if len(segments) == 1 {
+		return fn(data, segments[0])
+	}
+
+	return forEachMigrationSection(data[segments[0]], func(section map[string]interface{}) error {
+		return forEachMigrationKey(section, strings.Join(segments[1:], "/"), fn)
+	})
+}
+
+//forEachMigrationSection call fn with section value, or each element of section array
+func forEachMigrationSection(value interface{}, fn func(section map[string]interface{}) error) error {
+	switch tmp := value.(type) {
+	case map[string]interface{}:
+		return fn(tmp)
+	case []map[string]interface{}:
+		for _, section := range tmp {
+			if err := fn(section); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, element := range tmp {
+			if section, ok := element.(map[string]interface{}); ok {
+				if err := fn(section); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+//copyMigrationValue deep copy map and array so migration doesn't modify input data
+func copyMigrationValue(value interface{}) interface{} {
+	switch tmp := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(tmp))
+		for key, element := range tmp {
+			result[key] = copyMigrationValue(element)
+		}
+
+		return result
+	case []map[string]interface{}:
+		result := make([]interface{}, len(tmp))
+		for index, element := range tmp {
+			result[index] = copyMigrationValue(element)
+		}
+
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(tmp))
+		for index, element := range tmp {
+			result[index] = copyMigrationValue(element)
+		}
+
+		return result
+	}
+
+	return value
+}
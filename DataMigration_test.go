@@ -0,0 +1,248 @@
+package gxschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestParseMigrationFromXML(t *testing.T) {
+	rawXML := `<?xml version="1.0"?>
+<dxmigration id="1" from="2" to="3">
+	<rename path="items/remark" to="note"></rename>
+	<move from="street" to="address/street"></move>
+	<split from="fullName" to="firstName,lastName" separator=" "></split>
+	<default path="currency" value="MYR"></default>
+	<default path="items/discount" value="0" type="dxdecimal"></default>
+	<convert path="items/qty" to="dxint"></convert>
+</dxmigration>`
+
+	migration, err := ParseMigrationFromXML(rawXML)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	xmlStr, xmlErr := migration.XML()
+	if xmlErr != nil {
+		t.Error(xmlErr)
+		return
+	}
+
+	if strings.Compare(xmlStr, rawXML) != 0 {
+		t.Errorf("XML output not tally with [output]: \n%s\n\n[expected]:\n%s", xmlStr, rawXML)
+	}
+
+	invalidXMLs := []string{
+		`<dxmigration from="1" to="2"></dxmigration>`,
+		`<dxmigration id="1" from="a" to="2"></dxmigration>`,
+		`<dxmigration id="1" from="1" to="2"><drop path="remark"></drop></dxmigration>`,
+		`<dxmigration id="1" from="1" to="2"><rename path="remark"></rename></dxmigration>`,
+		`<dxmigration id="1" from="1" to="2"><convert path="qty" to="dxdate"></convert></dxmigration>`,
+		`<dxmigration id="1" from="1" to="2"><default path="qty" value="abc" type="dxint"></default></dxmigration>`,
+		`<dxmigration id="1" from="1" to="2"><split from="fullName" to="" separator=" "></split></dxmigration>`,
+		`<dxmigration id="1" from="1" to="2"><split from="fullName" to="firstName,,lastName" separator=" "></split></dxmigration>`,
+		`<dxmigration id="1" from="1" to="2"><split from="fullName" to="firstName,lastName" separator=""></split></dxmigration>`,
+	}
+
+	for _, invalidXML := range invalidXMLs {
+		if _, err := ParseMigrationFromXML(invalidXML); err == nil {
+			t.Errorf("Expect error occured on invalid migration: %s", invalidXML)
+		}
+	}
+}
+
+func TestMigrator_Migrate(t *testing.T) {
+	target, targetErr := ParseSchemaFromXML(`<dxdoc name="invoice" revision="3" id="1">
+	<dxstr name="firstName"></dxstr>
+	<dxstr name="lastName" isOptional="true"></dxstr>
+	<dxstr name="currency"></dxstr>
+	<dxsection name="address">
+		<dxstr name="street"></dxstr>
+	</dxsection>
+	<dxsection name="items" isArray="true">
+		<dxstr name="sku"></dxstr>
+		<dxint name="qty"></dxint>
+		<dxdecimal name="discount" precision="2"></dxdecimal>
+		<dxstr name="note" isOptional="true"></dxstr>
+	</dxsection>
+</dxdoc>`)
+	if targetErr != nil {
+		t.Error(targetErr)
+		return
+	}
+
+	migrator := NewMigrator("1")
+
+	if err := migrator.AddSchema(target); err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, rawXML := range []string{
+		`<dxmigration id="1" from="1" to="2">
+	<split from="fullName" to="firstName,lastName" separator=" "></split>
+	<rename path="items/remark" to="note"></rename>
+</dxmigration>`,
+		`<dxmigration id="1" from="2" to="3">
+	<move from="street" to="address/street"></move>
+	<default path="currency" value="MYR"></default>
+	<default path="items/discount" value="0" type="dxdecimal"></default>
+	<convert path="items/qty" to="dxint"></convert>
+</dxmigration>`,
+	} {
+		migration, err := ParseMigrationFromXML(rawXML)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := migrator.AddMigration(migration); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	input := map[string]interface{}{
+		"fullName": "John Smith",
+		"street":   "Jalan 1",
+		"items": []interface{}{
+			map[string]interface{}{"sku": "A1", "qty": "2", "remark": "fragile"},
+			map[string]interface{}{"sku": "B2", "qty": 3.0, "discount": decimal.New(5, -1)},
+		},
+	}
+
+	result, err := migrator.Migrate(input, 1, 3)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if result["firstName"] != "John" || result["lastName"] != "Smith" || result["currency"] != "MYR" {
+		t.Errorf("Unexpected migrated data: %v", result)
+	}
+
+	if address, ok := result["address"].(map[string]interface{}); !ok || address["street"] != "Jalan 1" {
+		t.Errorf("Expect street is moved into address but get: %v", result["address"])
+	}
+
+	items := result["items"].([]interface{})
+	first := items[0].(map[string]interface{})
+	if first["qty"] != 2 || first["note"] != "fragile" || !first["discount"].(decimal.Decimal).IsZero() {
+		t.Errorf("Unexpected migrated array element: %v", first)
+	}
+
+	if _, ok := input["fullName"]; !ok {
+		t.Error("Expect input data is not modified")
+	}
+
+	if _, err := migrator.Migrate(map[string]interface{}{"fullName": "John", "items": []interface{}{}}, 1, 3); err == nil {
+		t.Error("Expect error occured due to migrated data misses address")
+	} else if _, ok := err.(*ValidationReport); !ok {
+		t.Errorf("Expect validation report but get %v", err)
+	}
+
+	if _, err := migrator.Migrate(input, 0, 3); err == nil {
+		t.Error("Expect error occured due to no migration from revision 0")
+	}
+
+	if _, err := migrator.Migrate(input, 1, 2); err == nil {
+		t.Error("Expect error occured due to schema revision 2 is not added")
+	}
+
+	badQty := map[string]interface{}{"fullName": "John Smith", "street": "Jalan 1",
+		"items": []interface{}{map[string]interface{}{"sku": "A1", "qty": "two"}}}
+	if _, err := migrator.Migrate(badQty, 1, 3); err == nil {
+		t.Error("Expect error occured due to qty can't be converted")
+	}
+
+	if err := migrator.AddMigration(&Migration{ID: "1", From: 1, To: 3}); err == nil {
+		t.Error("Expect error occured due to migration from revision 1 is already added")
+	}
+
+	if err := migrator.AddMigration(&Migration{ID: "2", From: 3, To: 4}); err == nil {
+		t.Error("Expect error occured due to migration of other document")
+	}
+}
+
+func TestSplitStep_Apply_expectFail(t *testing.T) {
+	steps := []SplitStep{
+		{From: "fullName", Separator: " "},
+		{From: "fullName", To: []string{"firstName", ""}, Separator: " "},
+		{From: "fullName", To: []string{"firstName", "lastName"}},
+	}
+
+	for _, step := range steps {
+		data := map[string]interface{}{"fullName": "John Doe"}
+
+		if err := step.Apply(data); err == nil {
+			t.Errorf("Expect error occured on invalid split step: %#v", step)
+		}
+
+		if data["fullName"] != "John Doe" {
+			t.Errorf("Expect data is not modified by invalid split step %#v but get %v", step, data)
+		}
+	}
+}
+
+func TestConvertStep_Apply(t *testing.T) {
+	data := map[string]interface{}{
+		"codes":  []int{1, 2},
+		"qtys":   []string{"3", "4.0"},
+		"prices": []float64{1.5, 2},
+		"qty":    "5",
+	}
+
+	steps := []ConvertStep{
+		{Path: "codes", To: "dxstr"},
+		{Path: "qtys", To: "dxint"},
+		{Path: "prices", To: "dxdecimal"},
+		{Path: "qty", To: "dxint"},
+	}
+
+	for _, step := range steps {
+		if err := step.Apply(data); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	codes, ok := data["codes"].([]interface{})
+	if !ok || len(codes) != 2 || codes[0] != "1" || codes[1] != "2" {
+		t.Errorf("Expect codes converted into string array but get %#v", data["codes"])
+	}
+
+	qtys, ok := data["qtys"].([]interface{})
+	if !ok || len(qtys) != 2 || qtys[0] != 3 || qtys[1] != 4 {
+		t.Errorf("Expect qtys converted into int array but get %#v", data["qtys"])
+	}
+
+	prices, ok := data["prices"].([]interface{})
+	if !ok || len(prices) != 2 || !prices[0].(decimal.Decimal).Equal(decimal.New(15, -1)) {
+		t.Errorf("Expect prices converted into decimal array but get %#v", data["prices"])
+	}
+
+	if data["qty"] != 5 {
+		t.Errorf("Expect qty converted into int but get %#v", data["qty"])
+	}
+}
+
+func TestConvertStep_Apply_expectFail(t *testing.T) {
+	testCases := []map[string]interface{}{
+		{"qty": "1.5"},
+		{"qty": "99999999999999999999"},
+		{"qty": decimal.RequireFromString("-99999999999999999999")},
+		{"qty": []string{"1", "two"}},
+		{"qty": []float64{1, 1e30}},
+		{"qty": map[string]interface{}{"value": 1}},
+	}
+
+	step := ConvertStep{Path: "qty", To: "dxint"}
+
+	for _, data := range testCases {
+		if err := step.Apply(data); err == nil {
+			t.Errorf("Expect error occured on invalid conversion: %#v", data)
+		}
+	}
+}
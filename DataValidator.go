@@ -2,29 +2,188 @@ package gxschema
 
 import (
 	"encoding/json"
-	"encoding/xml"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/shopspring/decimal"
 )
 
 //ValidateDataFromXML validate data based on XML string
 func ValidateDataFromXML(dataXML string, docSchema *DxDoc) error {
+	report, err := ValidateAllFromXML(dataXML, docSchema)
+	if err != nil {
+		return err
+	}
+
+	return report.firstError()
+}
+
+//ValidateAllFromXML validate data based on XML string and report every violation found,
+//each violation carries line and column of offending element or attribute
+func ValidateAllFromXML(dataXML string, docSchema *DxDoc) (*ValidationReport, error) {
 	var n XMLNode
 
-	marshallErr := xml.Unmarshal([]byte(dataXML), &n)
-	if marshallErr != nil {
-		return fmt.Errorf("Failed to parse XML: %s", marshallErr.Error())
+	if err := unmarshalXMLNode(dataXML, &n); err != nil {
+		return nil, fmt.Errorf("Failed to parse XML: %s", err.Error())
+	}
+
+	return validateXMLNode(&n, dataXML, docSchema)
+}
+
+//validateXMLNode validate data parsed from XML root node, every violation is located at element or attribute
+//of its path, or nearest ancestor when value is missing
+func validateXMLNode(n *XMLNode, dataXML string, docSchema *DxDoc) (*ValidationReport, error) {
+	positions := make(map[string]xmlPosition)
+
+	input, err := parseDataFromXMLNode(n, dataXML, docSchema, positions)
+	if err != nil {
+		return nil, err
+	}
+
+	report := docSchema.ValidateAll(input)
+
+	for _, validationErr := range report.Errors {
+		path := validationErr.Path
+
+		for {
+			if position, ok := positions[path]; ok {
+				validationErr.Line, validationErr.Column = position.line, position.column
+				break
+			}
+
+			if path == "" {
+				break
+			}
+
+			path = path[:strings.LastIndex(path, "/")]
+		}
+	}
+
+	return report, nil
+}
+
+//parseDataFromXMLNode parse input data of document from XML root node,
+//positions is filled with position of every value by its JSON Pointer path, root element is at empty path
+func parseDataFromXMLNode(n *XMLNode, dataXML string, docSchema *DxDoc,
+	positions map[string]xmlPosition) (map[string]interface{}, error) {
+	if n.XMLName.Local != docSchema.Name {
+		return nil, fmt.Errorf("Invalid XML root element, expect %s: %s", docSchema.Name, dataXML)
+	}
+
+	positions[""] = n.position()
+
+	return parseXMLItems(n, docSchema.Items, "", true, positions), nil
+}
+
+//parseXMLItems parse attributes and child elements of node into values of items, each value is interpreted by
+//item which declares it; value of array item is always an array, even for single element.
+//Undeclared element is parsed by guessing its type, so strict document can still report it.
+//Position of every value is recorded by its path, path of array is located at its first element;
+//namespace declaration and schema reference attributes of root element are not data
+func parseXMLItems(node *XMLNode, items []DxItem, path string, isRoot bool,
+	positions map[string]xmlPosition) map[string]interface{} {
+	var names []string
+	valueNodes := make(map[string][]*XMLNode)
+
+	addNode := func(valueNode *XMLNode) {
+		name := valueNode.XMLName.Local
+		if _, ok := valueNodes[name]; !ok {
+			names = append(names, name)
+		}
+
+		valueNodes[name] = append(valueNodes[name], valueNode)
+	}
+
+	for index, attribute := range node.Attributes {
+		if isRoot && (attribute.Name.Space != "" || attribute.Name.Local == "xmlns" || attribute.Name.Local == "dxschema") {
+			continue
+		}
+
+		position := node.attributePosition(index)
+		addNode(&XMLNode{XMLName: attribute.Name, Data: attribute.Value, Line: position.line, Column: position.column})
+	}
+
+	for index := range node.Nodes {
+		addNode(&node.Nodes[index])
+	}
+
+	result := make(map[string]interface{})
+
+	for _, name := range names {
+		item := findItemByName(items, name)
+		namePath := path + jsonPointer(name)
+
+		if len(valueNodes[name]) > 1 || (item != nil && item.IsValueArray()) {
+			positions[namePath] = valueNodes[name][0].position()
+
+			values := make([]interface{}, len(valueNodes[name]))
+			for index, valueNode := range valueNodes[name] {
+				values[index] = parseXMLValue(valueNode, item, namePath+jsonPointer(index), positions)
+			}
+
+			result[name] = values
+		} else {
+			result[name] = parseXMLValue(valueNodes[name][0], item, namePath, positions)
+		}
+	}
+
+	return result
+}
+
+//parseXMLValue parse single element (or attribute) into GO type accepted by item,
+//text which can't be parsed is kept as string so validation reports it
+func parseXMLValue(node *XMLNode, item DxItem, path string, positions map[string]xmlPosition) interface{} {
+	positions[path] = node.position()
+
+	if item == nil {
+		return parseMapInterfaceFromXMLNode(node)[node.XMLName.Local]
+	}
+
+	switch tmp := dereferenceItem(item).(type) {
+	case DxSection:
+		return parseXMLItems(node, tmp.Items, path, false, positions)
+	case DxFile:
+		file := make(map[string]interface{})
+		for _, attribute := range node.Attributes {
+			file[attribute.Name.Local] = attribute.Value
+		}
+
+		for _, subNode := range node.Nodes {
+			file[subNode.XMLName.Local] = subNode.Data
+		}
+
+		return file
 	}
 
-	mapValue := parseMapInterfaceFromXMLNode(&n)
+	if len(node.Nodes) > 0 {
+		return parseMapInterfaceFromXMLNode(node)[node.XMLName.Local]
+	} else if node.Data == "" {
+		//empty element is empty string for string item, no value for others
+		switch dereferenceItem(item).(type) {
+		case DxStr, DxEnum:
+			return ""
+		}
 
-	if tmpMap, mapOK := mapValue[docSchema.Name].(map[string]interface{}); mapOK {
-		return docSchema.ValidateData(tmpMap)
+		return nil
+	}
+
+	switch dereferenceItem(item).(type) {
+	case DxInt:
+		if value, err := strconv.Atoi(node.Data); err == nil {
+			return value
+		}
+	case DxDecimal:
+		if value, err := decimal.NewFromString(node.Data); err == nil {
+			return value
+		}
+	case DxBool:
+		if value, err := strconv.ParseBool(node.Data); err == nil {
+			return value
+		}
 	}
 
-	return fmt.Errorf("Invalid XML root element, expect %s: %s", docSchema.Name, dataXML)
+	return node.Data
 }
 
 //parseMapInterfaceFromXMLNode parse map[string]interface{} from XMLNode
@@ -76,12 +235,121 @@ func parseMapInterfaceFromXMLNode(node *XMLNode) map[string]interface{} {
 
 //ValidateDataFromJSON validate data based on JSON string
 func ValidateDataFromJSON(dataJSON string, docSchema *DxDoc) error {
+	rawMap, err := parseDataFromJSON(dataJSON)
+	if err != nil {
+		return err
+	}
+
+	return docSchema.ValidateData(rawMap)
+}
+
+//ValidateAllFromJSON validate data based on JSON string and report every violation found
+func ValidateAllFromJSON(dataJSON string, docSchema *DxDoc) (*ValidationReport, error) {
+	rawMap, err := parseDataFromJSON(dataJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return docSchema.ValidateAll(rawMap), nil
+}
+
+//parseDataFromJSON parse input data of document from JSON string
+func parseDataFromJSON(dataJSON string) (map[string]interface{}, error) {
 	rawMap := make(map[string]interface{})
 
 	parseErr := json.Unmarshal([]byte(dataJSON), &rawMap)
 	if parseErr != nil {
-		return fmt.Errorf("Failed to parse JSON string: %s", parseErr.Error())
+		return nil, fmt.Errorf("Failed to parse JSON string: %s", parseErr.Error())
 	}
 
-	return docSchema.ValidateData(rawMap)
+	return rawMap, nil
+}
+
+//ValidateAuto validate JSON or XML data against document revision it declares, stop at first violation;
+//see ValidateAllAuto for envelope convention
+func ValidateAuto(rawData string, registry SchemaRegistry) error {
+	report, err := ValidateAllAuto(rawData, registry)
+	if err != nil {
+		return err
+	}
+
+	return report.firstError()
+}
+
+//ValidateAllAuto validate JSON or XML data against document revision it declares and report every violation found.
+//JSON data declares "$schema": "<ID>@<revision>" member which is not treated as data,
+//XML data declares dxschema="<ID>@<revision>" attribute on root element.
+//Error is returned when declared revision is not published (ErrSchemaNotFound) or is retired (ErrSchemaRetired)
+func ValidateAllAuto(rawData string, registry SchemaRegistry) (*ValidationReport, error) {
+	trimmed := strings.TrimSpace(rawData)
+
+	if strings.HasPrefix(trimmed, "<") {
+		var n XMLNode
+
+		if err := unmarshalXMLNode(rawData, &n); err != nil {
+			return nil, fmt.Errorf("Failed to parse XML: %s", err.Error())
+		}
+
+		var reference string
+		for _, attribute := range n.Attributes {
+			if attribute.Name.Local == "dxschema" {
+				reference = attribute.Value
+			}
+		}
+
+		if reference == "" {
+			return nil, fmt.Errorf("XML root element missing attribute 'dxschema'")
+		}
+
+		docSchema, err := resolveSchemaReference(reference, registry)
+		if err != nil {
+			return nil, err
+		}
+
+		return validateXMLNode(&n, rawData, docSchema)
+	}
+
+	input, err := parseDataFromJSON(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	reference, ok := input["$schema"].(string)
+	if !ok {
+		return nil, fmt.Errorf("JSON data missing string member '$schema'")
+	}
+
+	delete(input, "$schema")
+
+	docSchema, err := resolveSchemaReference(reference, registry)
+	if err != nil {
+		return nil, err
+	}
+
+	return docSchema.ValidateAll(input), nil
+}
+
+//resolveSchemaReference get document revision referred by <ID>@<revision> from registry
+func resolveSchemaReference(reference string, registry SchemaRegistry) (*DxDoc, error) {
+	separator := strings.LastIndex(reference, "@")
+	if separator <= 0 {
+		return nil, fmt.Errorf("schema reference expect <ID>@<revision> but get '%s'", reference)
+	}
+
+	id := reference[:separator]
+	revision, err := strconv.Atoi(reference[separator+1:])
+	if err != nil {
+		return nil, fmt.Errorf("schema reference expect <ID>@<revision> but get '%s'", reference)
+	}
+
+	retired, err := registry.IsRetired(id, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	if retired {
+		return nil, fmt.Errorf("%w: %s", ErrSchemaRetired, reference)
+	}
+
+	return registry.Get(id, revision)
 }
@@ -2,6 +2,7 @@ package gxschema
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -224,3 +225,255 @@ func TestValidateDataFromJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAllFromJSON(t *testing.T) {
+	docSchema := &DxDoc{
+		Name:     "order",
+		Revision: 1,
+		Items: []DxItem{
+			DxStr{Name: "orderNo"},
+			DxSection{Name: "items", IsArray: true, Items: []DxItem{
+				DxStr{Name: "sku"},
+				DxInt{Name: "qty"},
+			}},
+		},
+	}
+
+	report, err := ValidateAllFromJSON(`{"orderNo": "ODR0001", "items": [
+		{"sku": "A001", "qty": 1},
+		{"sku": 2, "qty": 1.5},
+		{"sku": "A003"}
+	]}`, docSchema)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(report.Errors) != 3 {
+		t.Errorf("expect 3 violations but get %d:\n%s", len(report.Errors), report.Error())
+	}
+
+	if _, err := ValidateAllFromJSON(`{"orderNo": `, docSchema); err == nil {
+		t.Error("expect malformed JSON string fail")
+	}
+}
+
+func TestValidateAuto(t *testing.T) {
+	registry := NewMemorySchemaRegistry()
+
+	for revision := 1; revision <= 2; revision++ {
+		docSchema := &DxDoc{
+			Name:     "book",
+			ID:       "book-id",
+			Revision: revision,
+			IsStrict: true,
+			Items: []DxItem{
+				DxStr{Name: "author"},
+				DxInt{Name: "year", IsOptional: revision > 1},
+			},
+		}
+
+		if err := registry.Publish(docSchema); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := registry.Retire("book-id", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		rawData   string
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name:    "test JSON envelope",
+			rawData: `{"$schema": "book-id@2", "author": "John"}`,
+		},
+		{
+			name:    "test XML envelope",
+			rawData: `<book dxschema="book-id@2"><author>John</author><year>1997</year></book>`,
+		},
+		{
+			name:    "test invalid data",
+			rawData: `{"$schema": "book-id@2", "author": 12}`,
+			wantErr: true,
+		},
+		{
+			name:      "test retired revision",
+			rawData:   `{"$schema": "book-id@1", "author": "John", "year": 1997}`,
+			wantErr:   true,
+			wantErrIs: ErrSchemaRetired,
+		},
+		{
+			name:      "test unknown ID",
+			rawData:   `<book dxschema="novel-id@1"><author>John</author></book>`,
+			wantErr:   true,
+			wantErrIs: ErrSchemaNotFound,
+		},
+		{
+			name:    "test missing envelope",
+			rawData: `{"author": "John"}`,
+			wantErr: true,
+		},
+		{
+			name:    "test malformed reference",
+			rawData: `<book dxschema="book-id"><author>John</author></book>`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAuto(tt.rawData, registry)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAuto() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("ValidateAuto() error = %v, want %v", err, tt.wantErrIs)
+			}
+		})
+	}
+}
+
+func Test_parseDataFromXMLNode(t *testing.T) {
+	docSchema := &DxDoc{
+		Name:     "order",
+		Revision: 1,
+		Items: []DxItem{
+			DxStr{Name: "orderNo"},
+			DxStr{Name: "remark"},
+			DxInt{Name: "qty"},
+			DxBool{Name: "isPaid"},
+			DxDecimal{Name: "amount", Precision: 2},
+			DxStr{Name: "tags", IsArray: true},
+			&DxSection{Name: "lines", IsArray: true, Items: []DxItem{
+				DxStr{Name: "sku"},
+				DxInt{Name: "qty"},
+			}},
+			DxFile{Name: "attachment"},
+		},
+	}
+
+	parse := func(dataXML string) (map[string]interface{}, error) {
+		var n XMLNode
+		if err := unmarshalXMLNode(dataXML, &n); err != nil {
+			return nil, err
+		}
+
+		return parseDataFromXMLNode(&n, dataXML, docSchema, make(map[string]xmlPosition))
+	}
+
+	input, err := parse(`<order orderNo="123" dxschema="order-id@1">
+	<remark>true</remark>
+	<qty>2</qty>
+	<isPaid>false</isPaid>
+	<amount>12</amount>
+	<tags>urgent</tags>
+	<lines sku="007" dxschema="1"><qty>1</qty></lines>
+	<attachment><filename>a.pdf</filename><filepath>/tmp/a.pdf</filepath></attachment>
+	<note>1</note>
+</order>`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := map[string]interface{}{
+		"orderNo": "123",
+		"remark":  "true",
+		"qty":     2,
+		"isPaid":  false,
+		"amount":  decimal.NewFromInt(12),
+		"tags":    []interface{}{"urgent"},
+		"lines": []interface{}{
+			//dxschema attribute is data except on root element
+			map[string]interface{}{"sku": "007", "qty": 1, "dxschema": 1},
+		},
+		"attachment": map[string]interface{}{"filename": "a.pdf", "filepath": "/tmp/a.pdf"},
+		"note":       1,
+	}
+
+	if !reflect.DeepEqual(input, expected) {
+		t.Errorf("Expect parsed data:\n%v\nbut get:\n%v", expected, input)
+	}
+
+	delete(input, "note")
+
+	if err := docSchema.ValidateData(input); err != nil {
+		t.Error(err)
+	}
+
+	if err := ValidateDataFromXML(`<order orderNo="A1"><remark>x</remark><qty>two</qty><isPaid>true</isPaid>`+
+		`<amount>1</amount><tags>a</tags><lines><sku>1</sku><qty>1</qty></lines>`+
+		`<attachment><filename>a</filename><filepath>b</filepath></attachment></order>`, docSchema); err == nil {
+		t.Error("Expect error occured due to qty is not integer")
+	}
+
+	emptyInput, emptyErr := parse(`<order><orderNo></orderNo><remark/><qty></qty></order>`)
+	if emptyErr != nil {
+		t.Error(emptyErr)
+		return
+	}
+
+	if emptyInput["orderNo"] != "" || emptyInput["remark"] != "" || emptyInput["qty"] != nil {
+		t.Errorf("Expect empty string for empty dxstr element and nil for others but get: %v", emptyInput)
+	}
+}
+
+func TestValidateAllFromXML_position(t *testing.T) {
+	docSchema := &DxDoc{
+		Name:     "order",
+		Revision: 1,
+		Items: []DxItem{
+			DxStr{Name: "orderNo"},
+			DxInt{Name: "qty"},
+			DxBool{Name: "isPaid"},
+			&DxSection{Name: "lines", IsArray: true, Items: []DxItem{
+				DxStr{Name: "sku"},
+				DxInt{Name: "qty"},
+			}},
+		},
+	}
+
+	report, err := ValidateAllFromXML(`<order orderNo="A1" isPaid="maybe">
+	<qty>two</qty>
+	<lines><sku>1</sku><qty>1</qty></lines>
+	<lines><qty>x</qty></lines>
+</order>`, docSchema)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := map[string][2]int{
+		"/isPaid":      {1, 21}, //attribute
+		"/qty":         {2, 2},
+		"/lines/1/qty": {4, 9},
+		"/lines/1/sku": {4, 2}, //missing value is located at its section
+	}
+
+	if len(report.Errors) != len(expected) {
+		t.Errorf("Expect %d violations but get %d:\n%s", len(expected), len(report.Errors), report.Error())
+	}
+
+	for _, validationErr := range report.Errors {
+		position, ok := expected[validationErr.Path]
+		if !ok {
+			t.Errorf("Unexpected violation: %s", validationErr.Error())
+			continue
+		}
+
+		if validationErr.Line != position[0] || validationErr.Column != position[1] {
+			t.Errorf("Expect %s is located at line %d, column %d but get: %s",
+				validationErr.Path, position[0], position[1], validationErr.Error())
+		}
+	}
+
+	if err := ValidateDataFromXML("<order orderNo=\"A1\" isPaid=\"true\">\n<qty>1</qty>\n</order>", docSchema); err == nil ||
+		!strings.Contains(err.Error(), "(line 1, column 1)") {
+		t.Errorf("Expect missing lines is located at root element but get: %v", err)
+	}
+}
@@ -1,15 +1,17 @@
 package gxschema
 
 import (
-	"fmt"
 	"reflect"
 )
 
 //DxBool boolean data type
 type DxBool struct {
-	Name       string
-	IsOptional bool
-	IsArray    bool
+	Name        string
+	IsOptional  bool
+	IsArray     bool
+	MinItems    int  //MinItems least number of array elements, 0 means no limit
+	MaxItems    int  //MaxItems most number of array elements, 0 means no limit
+	UniqueItems bool //UniqueItems array elements must be distinct
 }
 
 //GetName get name
@@ -37,51 +39,74 @@ func (item DxBool) XML(indentLevel int) string {
 		result += " isOptional=\"true\""
 	}
 
+	result += item.arraySpec().xmlAttributes()
+
 	return result + "></dxbool>"
 }
 
 //ValidateData validate input data
 func (item DxBool) ValidateData(input map[string]interface{}, name string) error {
+	return validateFirst(item, input, name)
+}
+
+//CollectErrors validate input data and add every violation into report
+func (item DxBool) CollectErrors(input map[string]interface{}, name string, report *ValidationReport) {
+	item.arraySpec().collectErrors(item, input, name, report)
+
+	if report.isDone() {
+		return
+	}
+
 	rawValue, keyOK := input[name]
 
 	if !keyOK {
 		if !item.IsOptional {
-			return fmt.Errorf("map entry '%s' is not exists", name)
+			report.Add(newValidationError(item, jsonPointer(name), CodeMissing, nil, nil, "is not exists"))
 		}
 
-		return nil
+		return
 	} else if rawValue == nil && item.IsOptional {
-		return nil
+		return
 	}
 
 	if item.IsArray {
 		_, arrOK := rawValue.([]bool)
 		if arrOK {
 
-			return nil
+			return
 		}
 
 		arrBool, arrBoolOK := rawValue.([]interface{})
 		if arrBoolOK {
 			for index, tmp := range arrBool {
+				if report.isDone() {
+					return
+				}
+
 				_, OK := tmp.(bool)
 				if !OK {
-					return fmt.Errorf("%s[%d] is not boolean but %s", name, index, reflect.TypeOf(tmp))
+					report.Add(newValidationError(item, jsonPointer(name, index), CodeWrongType, "boolean", tmp,
+						"is not boolean but %s", reflect.TypeOf(tmp)))
 				}
 			}
 
-			return nil
+			return
 		} else if _, intOK := rawValue.(bool); intOK {
-			return nil
+			return
 		}
 
-		return fmt.Errorf("%s is not array boolean but %s", name, reflect.TypeOf(rawValue))
+		report.Add(newValidationError(item, jsonPointer(name), CodeWrongType, "array boolean", rawValue,
+			"is not array boolean but %s", reflect.TypeOf(rawValue)))
+		return
 	}
 
 	_, intOK := rawValue.(bool)
 	if !intOK {
-		return fmt.Errorf("%s is not boolean but %s", name, reflect.TypeOf(rawValue))
+		report.Add(newValidationError(item, jsonPointer(name), CodeWrongType, "boolean", rawValue,
+			"is not boolean but %s", reflect.TypeOf(rawValue)))
 	}
+}
 
-	return nil
+func (item DxBool) arraySpec() arraySpec {
+	return arraySpec{minItems: item.MinItems, maxItems: item.MaxItems, uniqueItems: item.UniqueItems}
 }
@@ -0,0 +1,73 @@
+package gxschema
+
+import "time"
+
+//DxDate date data type
+//accepted value:
+//		1. ISO 8601 calendar date string, e.g. 2006-01-02
+//		2. time.Time, only date part is considered
+type DxDate struct {
+	Name        string
+	IsOptional  bool
+	IsArray     bool
+	MinItems    int       //MinItems least number of array elements, 0 means no limit
+	MaxItems    int       //MaxItems most number of array elements, 0 means no limit
+	UniqueItems bool      //UniqueItems array elements must be distinct
+	Format      string    //Format custom GO time layout, default is ISO 8601
+	Min         time.Time //Min earliest accepted date, zero value means no limit
+	Max         time.Time //Max latest accepted date, zero value means no limit
+}
+
+//GetName get name
+func (item DxDate) GetName() string { return item.Name }
+
+//IsValueOptional is field value optional
+func (item DxDate) IsValueOptional() bool { return item.IsOptional }
+
+//IsValueArray is field value allow to store multiple values
+func (item DxDate) IsValueArray() bool { return item.IsArray }
+
+//XML generate XML
+func (item DxDate) XML(indentLevel int) string {
+	var result string
+	for i := 0; i < indentLevel; i++ {
+		result += "\t"
+	}
+	result += "<dxdate name=\"" + item.Name + "\""
+
+	if item.IsArray {
+		result += " isArray=\"true\""
+	}
+
+	if item.IsOptional {
+		result += " isOptional=\"true\""
+	}
+
+	result += item.arraySpec().xmlAttributes()
+
+	return result + item.temporal().xmlAttributes() + "></dxdate>"
+}
+
+//ValidateData validate input data
+func (item DxDate) ValidateData(input map[string]interface{}, name string) error {
+	return validateFirst(item, input, name)
+}
+
+//CollectErrors validate input data and add every violation into report
+func (item DxDate) CollectErrors(input map[string]interface{}, name string, report *ValidationReport) {
+	item.arraySpec().collectErrors(item, input, name, report)
+
+	if report.isDone() {
+		return
+	}
+
+	item.temporal().collectErrors(item, input, name, report)
+}
+
+func (item DxDate) temporal() temporalSpec {
+	return temporalSpec{kind: temporalDate, format: item.Format, min: item.Min, max: item.Max}
+}
+
+func (item DxDate) arraySpec() arraySpec {
+	return arraySpec{minItems: item.MinItems, maxItems: item.MaxItems, uniqueItems: item.UniqueItems}
+}
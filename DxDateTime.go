@@ -0,0 +1,327 @@
+package gxschema
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+//DxDateTime date time data type
+//accepted value:
+//		1. ISO 8601 combined date and time string, e.g. 2006-01-02T15:04:05+08:00
+//		2. time.Time
+type DxDateTime struct {
+	Name            string
+	IsOptional      bool
+	IsArray         bool
+	MinItems        int       //MinItems least number of array elements, 0 means no limit
+	MaxItems        int       //MaxItems most number of array elements, 0 means no limit
+	UniqueItems     bool      //UniqueItems array elements must be distinct
+	Format          string    //Format custom GO time layout, default is ISO 8601
+	Min             time.Time //Min earliest accepted value, zero value means no limit
+	Max             time.Time //Max latest accepted value, zero value means no limit
+	RequireTimezone bool      //RequireTimezone value must declare its UTC offset
+}
+
+//GetName get name
+func (item DxDateTime) GetName() string { return item.Name }
+
+//IsValueOptional is field value optional
+func (item DxDateTime) IsValueOptional() bool { return item.IsOptional }
+
+//IsValueArray is field value allow to store multiple values
+func (item DxDateTime) IsValueArray() bool { return item.IsArray }
+
+//XML generate XML
+func (item DxDateTime) XML(indentLevel int) string {
+	var result string
+	for i := 0; i < indentLevel; i++ {
+		result += "\t"
+	}
+	result += "<dxdatetime name=\"" + item.Name + "\""
+
+	if item.IsArray {
+		result += " isArray=\"true\""
+	}
+
+	if item.IsOptional {
+		result += " isOptional=\"true\""
+	}
+
+	result += item.arraySpec().xmlAttributes()
+
+	return result + item.temporal().xmlAttributes() + "></dxdatetime>"
+}
+
+//ValidateData validate input data
+func (item DxDateTime) ValidateData(input map[string]interface{}, name string) error {
+	return validateFirst(item, input, name)
+}
+
+//CollectErrors validate input data and add every violation into report
+func (item DxDateTime) CollectErrors(input map[string]interface{}, name string, report *ValidationReport) {
+	item.arraySpec().collectErrors(item, input, name, report)
+
+	if report.isDone() {
+		return
+	}
+
+	item.temporal().collectErrors(item, input, name, report)
+}
+
+func (item DxDateTime) temporal() temporalSpec {
+	return temporalSpec{kind: temporalDateTime, format: item.Format,
+		min: item.Min, max: item.Max, requireTimezone: item.RequireTimezone}
+}
+
+type temporalKind int
+
+const (
+	temporalDate temporalKind = iota
+	temporalTime
+	temporalDateTime
+)
+
+//String data type name used in error message
+func (kind temporalKind) String() string {
+	switch kind {
+	case temporalDate:
+		return "date"
+	case temporalTime:
+		return "time"
+	default:
+		return "datetime"
+	}
+}
+
+//ISO 8601 layouts accepted when no custom format is declared,
+//layouts with UTC offset must come first
+var temporalLayouts = map[temporalKind][]string{
+	temporalDate:     {"2006-01-02"},
+	temporalTime:     {"15:04:05Z07:00", "15:04Z07:00", "15:04:05", "15:04"},
+	temporalDateTime: {time.RFC3339, "2006-01-02T15:04Z07:00", "2006-01-02T15:04:05", "2006-01-02T15:04"},
+}
+
+//layouts used to write min and max value into XML when no custom format is declared
+var temporalCanonicalLayouts = map[temporalKind]string{
+	temporalDate:     "2006-01-02",
+	temporalTime:     "15:04:05.999999999Z07:00",
+	temporalDateTime: time.RFC3339Nano,
+}
+
+//temporalSpec common definition shared by DxDate, DxTime and DxDateTime
+type temporalSpec struct {
+	kind            temporalKind
+	format          string
+	min             time.Time
+	max             time.Time
+	requireTimezone bool
+}
+
+//parse parse string value, second return value tell whether value declares UTC offset
+func (spec temporalSpec) parse(value string) (time.Time, bool, error) {
+	if spec.format != "" {
+		result, err := time.Parse(spec.format, value)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+
+		return result, isLayoutHasTimezone(spec.format), nil
+	}
+
+	var lastErr error
+	for _, layout := range temporalLayouts[spec.kind] {
+		result, err := time.Parse(layout, value)
+		if err == nil {
+			return result, isLayoutHasTimezone(layout), nil
+		}
+
+		lastErr = err
+	}
+
+	return time.Time{}, false, lastErr
+}
+
+//formatValue format value for XML output
+func (spec temporalSpec) formatValue(value time.Time) string {
+	if spec.format != "" {
+		return value.Format(spec.format)
+	}
+
+	return value.Format(temporalCanonicalLayouts[spec.kind])
+}
+
+//pattern regular expression (without anchors) matching every value accepted by temporalLayouts;
+//parsing allows single digit hour and fractional second, UTC offset is optional unless requireTimezone is set
+func (spec temporalSpec) pattern() string {
+	date := `\d{4}-\d{2}-\d{2}`
+	if spec.kind == temporalDate {
+		return date
+	}
+
+	result := `\d{1,2}:\d{2}(:\d{2}([.,]\d+)?)?(Z|[+\-]\d{2}:\d{2})`
+	if !spec.requireTimezone {
+		result += "?"
+	}
+
+	if spec.kind == temporalDateTime {
+		result = date + "T" + result
+	}
+
+	return result
+}
+
+//compare compare two values based on data type, return -1, 0 or 1
+func (spec temporalSpec) compare(a, b time.Time) int {
+	switch spec.kind {
+	case temporalDate:
+		a = time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+		b = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	case temporalTime:
+		//time of day is compared by wall clock
+		a = time.Date(0, 1, 1, a.Hour(), a.Minute(), a.Second(), a.Nanosecond(), time.UTC)
+		b = time.Date(0, 1, 1, b.Hour(), b.Minute(), b.Second(), b.Nanosecond(), time.UTC)
+	}
+
+	if a.Before(b) {
+		return -1
+	} else if a.After(b) {
+		return 1
+	}
+
+	return 0
+}
+
+func (spec temporalSpec) xmlAttributes() string {
+	var result string
+
+	if spec.format != "" {
+		result += " format=\"" + escapeXMLAttribute(spec.format) + "\""
+	}
+
+	if !spec.min.IsZero() {
+		result += " min=\"" + escapeXMLAttribute(spec.formatValue(spec.min)) + "\""
+	}
+
+	if !spec.max.IsZero() {
+		result += " max=\"" + escapeXMLAttribute(spec.formatValue(spec.max)) + "\""
+	}
+
+	if spec.requireTimezone {
+		result += " requireTimezone=\"true\""
+	}
+
+	return result
+}
+
+func (spec temporalSpec) collectErrors(item DxItem, input map[string]interface{}, name string,
+	report *ValidationReport) {
+	rawValue, keyOK := input[name]
+
+	if !keyOK {
+		if !item.IsValueOptional() {
+			report.Add(newValidationError(item, jsonPointer(name), CodeMissing, nil, nil, "is not exists"))
+		}
+
+		return
+	} else if rawValue == nil && item.IsValueOptional() {
+		return
+	}
+
+	if item.IsValueArray() {
+		var values []interface{}
+
+		if arrStr, arrOK := rawValue.([]string); arrOK {
+			for _, value := range arrStr {
+				values = append(values, value)
+			}
+		} else if arrTime, arrOK := rawValue.([]time.Time); arrOK {
+			for _, value := range arrTime {
+				values = append(values, value)
+			}
+		} else if arrObj, arrObjOK := rawValue.([]interface{}); arrObjOK {
+			values = arrObj
+		} else {
+			switch rawValue.(type) {
+			case string, time.Time:
+			default:
+				report.Add(newValidationError(item, jsonPointer(name), CodeWrongType, "array "+spec.kind.String(),
+					rawValue, "is not array %s but %s", spec.kind, reflect.TypeOf(rawValue)))
+				return
+			}
+
+			//single value is accepted as array of one element
+			spec.validateValue(item, rawValue, jsonPointer(name), report)
+			return
+		}
+
+		for index, value := range values {
+			if report.isDone() {
+				return
+			}
+
+			spec.validateValue(item, value, jsonPointer(name, index), report)
+		}
+
+		return
+	}
+
+	spec.validateValue(item, rawValue, jsonPointer(name), report)
+}
+
+func (spec temporalSpec) validateValue(item DxItem, rawValue interface{}, path string, report *ValidationReport) {
+	var value time.Time
+
+	switch tmp := rawValue.(type) {
+	case string:
+		parsed, hasTimezone, err := spec.parse(tmp)
+		if err != nil {
+			report.Add(newValidationError(item, path, CodeFormat, spec.formatName(), tmp,
+				"is not valid %s: %s", spec.kind, tmp))
+			return
+		}
+
+		if spec.requireTimezone && !hasTimezone {
+			report.Add(newValidationError(item, path, CodeTimezone, "UTC offset", tmp,
+				"has no timezone: %s", tmp))
+			return
+		}
+
+		value = parsed
+	case time.Time:
+		value = tmp
+	default:
+		report.Add(newValidationError(item, path, CodeWrongType, spec.kind.String(), rawValue,
+			"is not %s but %s", spec.kind, reflect.TypeOf(rawValue)))
+		return
+	}
+
+	if !spec.min.IsZero() && spec.compare(value, spec.min) < 0 {
+		report.Add(newValidationError(item, path, CodeMin, spec.formatValue(spec.min), rawValue,
+			"is earlier than %s: %s", spec.formatValue(spec.min), spec.formatValue(value)))
+	}
+
+	if !spec.max.IsZero() && spec.compare(value, spec.max) > 0 {
+		report.Add(newValidationError(item, path, CodeMax, spec.formatValue(spec.max), rawValue,
+			"is later than %s: %s", spec.formatValue(spec.max), spec.formatValue(value)))
+	}
+}
+
+//formatName expected format of string value, used as ValidationError.Expected
+func (spec temporalSpec) formatName() string {
+	if spec.format != "" {
+		return spec.format
+	}
+
+	return "ISO 8601 " + spec.kind.String()
+}
+
+//isLayoutHasTimezone check GO time layout contains UTC offset or zone name
+func isLayoutHasTimezone(layout string) bool {
+	return strings.Contains(layout, "Z07") || strings.Contains(layout, "-07") ||
+		strings.Contains(layout, "MST")
+}
+
+func (item DxDateTime) arraySpec() arraySpec {
+	return arraySpec{minItems: item.MinItems, maxItems: item.MaxItems, uniqueItems: item.UniqueItems}
+}
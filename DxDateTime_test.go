@@ -0,0 +1,71 @@
+package gxschema
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDxDateTime_ValidateData(t *testing.T) {
+	type args struct {
+		input map[string]interface{}
+		name  string
+	}
+	tests := []struct {
+		name    string
+		item    DxDateTime
+		args    args
+		wantErr bool
+	}{
+		// Add test cases.
+		{
+			name:    "simple datetime test",
+			item:    DxDateTime{Name: "createdAt"},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "2018-06-07T14:48:47+08:00"}},
+			wantErr: false,
+		},
+		{
+			name:    "datetime without offset test",
+			item:    DxDateTime{Name: "createdAt"},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "2018-06-07T14:48:47"}},
+			wantErr: false,
+		},
+		{
+			name:    "timezone missing test",
+			item:    DxDateTime{Name: "createdAt", RequireTimezone: true},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "2018-06-07T14:48:47"}},
+			wantErr: true,
+		},
+		{
+			name:    "time.Time test",
+			item:    DxDateTime{Name: "createdAt", RequireTimezone: true},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": time.Now()}},
+			wantErr: false,
+		},
+		{
+			name:    "date only test",
+			item:    DxDateTime{Name: "createdAt"},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "2018-06-07"}},
+			wantErr: true,
+		},
+		{
+			name: "datetime range test",
+			item: DxDateTime{Name: "createdAt",
+				Min: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "2018-01-01T07:59:59+08:00"}},
+			wantErr: true,
+		},
+		{
+			name:    "datetime array test",
+			item:    DxDateTime{Name: "createdAt", IsArray: true},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": []time.Time{time.Now(), time.Now()}}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.item.ValidateData(tt.args.input, tt.args.name); (err != nil) != tt.wantErr {
+				t.Errorf("DxDateTime.ValidateData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
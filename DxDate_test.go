@@ -0,0 +1,104 @@
+package gxschema
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDxDate_ValidateData(t *testing.T) {
+	type args struct {
+		input map[string]interface{}
+		name  string
+	}
+	tests := []struct {
+		name    string
+		item    DxDate
+		args    args
+		wantErr bool
+	}{
+		// Add test cases.
+		{
+			name:    "simple date test",
+			item:    DxDate{Name: "dob"},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "1986-04-22"}},
+			wantErr: false,
+		},
+		{
+			name:    "time.Time date test",
+			item:    DxDate{Name: "dob"},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": time.Date(1986, 4, 22, 13, 0, 0, 0, time.UTC)}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid date test",
+			item:    DxDate{Name: "dob"},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "1986-02-30"}},
+			wantErr: true,
+		},
+		{
+			name:    "wrong type test",
+			item:    DxDate{Name: "dob"},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": 19860422}},
+			wantErr: true,
+		},
+		{
+			name:    "custom format test",
+			item:    DxDate{Name: "dob", Format: "02/01/2006"},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "22/04/1986"}},
+			wantErr: false,
+		},
+		{
+			name:    "custom format mismatch test",
+			item:    DxDate{Name: "dob", Format: "02/01/2006"},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "1986-04-22"}},
+			wantErr: true,
+		},
+		{
+			name: "date range test",
+			item: DxDate{Name: "dob",
+				Min: time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+				Max: time.Date(2000, 12, 31, 0, 0, 0, 0, time.UTC)},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "2000-12-31"}},
+			wantErr: false,
+		},
+		{
+			name: "date earlier than min test",
+			item: DxDate{Name: "dob",
+				Min: time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "1899-12-31"}},
+			wantErr: true,
+		},
+		{
+			name: "date later than max test",
+			item: DxDate{Name: "dob",
+				Max: time.Date(2000, 12, 31, 0, 0, 0, 0, time.UTC)},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "2001-01-01"}},
+			wantErr: true,
+		},
+		{
+			name:    "date array test",
+			item:    DxDate{Name: "holidays", IsArray: true},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": []string{"2018-01-01", "2018-12-25"}}},
+			wantErr: false,
+		},
+		{
+			name:    "date array test 2",
+			item:    DxDate{Name: "holidays", IsArray: true},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": []interface{}{"2018-01-01", "2018-13-25"}}},
+			wantErr: true,
+		},
+		{
+			name:    "null value test",
+			item:    DxDate{Name: "dob", IsOptional: true},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": nil}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.item.ValidateData(tt.args.input, tt.args.name); (err != nil) != tt.wantErr {
+				t.Errorf("DxDate.ValidateData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
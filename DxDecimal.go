@@ -10,10 +10,19 @@ import (
 
 //DxDecimal floating point data type
 type DxDecimal struct {
-	Name       string
-	IsOptional bool
-	IsArray    bool
-	Precision  int //decimal precision
+	Name         string
+	IsOptional   bool
+	IsArray      bool
+	MinItems     int  //MinItems least number of array elements, 0 means no limit
+	MaxItems     int  //MaxItems most number of array elements, 0 means no limit
+	UniqueItems  bool //UniqueItems array elements must be distinct
+	Precision    int  //decimal precision
+	EnableMin    bool //EnableMin value must not less than Min
+	Min          decimal.Decimal
+	ExclusiveMin bool //ExclusiveMin value must greater than Min
+	EnableMax    bool //EnableMax value must not greater than Max
+	Max          decimal.Decimal
+	ExclusiveMax bool //ExclusiveMax value must less than Max
 }
 
 //GetName get name
@@ -41,78 +50,95 @@ func (item DxDecimal) XML(indentLevel int) string {
 		result += " isOptional=\"true\""
 	}
 
-	return result + fmt.Sprintf(" precision=\"%d\"></dxdecimal>", item.Precision)
+	result += item.arraySpec().xmlAttributes()
+
+	return result + fmt.Sprintf(" precision=\"%d\"", item.Precision) +
+		item.numericRange().xmlAttributes() + "></dxdecimal>"
 }
 
 //ValidateData validate input data
 func (item DxDecimal) ValidateData(input map[string]interface{}, name string) error {
+	return validateFirst(item, input, name)
+}
+
+//CollectErrors validate input data and add every violation into report
+func (item DxDecimal) CollectErrors(input map[string]interface{}, name string, report *ValidationReport) {
+	item.arraySpec().collectErrors(item, input, name, report)
+
+	if report.isDone() {
+		return
+	}
+
 	rawValue, keyOK := input[name]
 
 	if !keyOK {
 		if !item.IsOptional {
-			return fmt.Errorf("map entry '%s' is not exists", name)
+			report.Add(newValidationError(item, jsonPointer(name), CodeMissing, nil, nil, "is not exists"))
 		}
 
-		return nil
+		return
 	} else if rawValue == nil && item.IsOptional {
-		return nil
+		return
 	}
 
 	if item.IsArray {
 		if arrFloat, arrOK := rawValue.([]float64); arrOK {
 			for index, value := range arrFloat {
-				if err := item.validateFloat(value,
-					fmt.Sprintf("%s[%d]", name, index)); err != nil {
-					return err
+				if report.isDone() {
+					return
 				}
+
+				item.validateFloat(value, jsonPointer(name, index), report)
 			}
 
-			return nil
+			return
 		} else if arrDecimal, arrOK := rawValue.([]decimal.Decimal); arrOK {
 			for index, value := range arrDecimal {
-				if err := item.validateShopSpringDecimal(value,
-					fmt.Sprintf("%s[%d]", name, index)); err != nil {
-					return err
+				if report.isDone() {
+					return
 				}
+
+				item.validateShopSpringDecimal(value, jsonPointer(name, index), report)
 			}
 
-			return nil
+			return
 		} else if arrObj, arrObjOK := rawValue.([]interface{}); arrObjOK {
 
 			for index, rawValue := range arrObj {
-				if value, floatOK := rawValue.(float64); floatOK {
-					if err := item.validateFloat(value, fmt.Sprintf("%s[%d]", name, index)); err != nil {
-						return err
-					}
-				} else if value, shopspringDecimalOK := rawValue.(decimal.Decimal); shopspringDecimalOK {
-					if err := item.validateShopSpringDecimal(value, fmt.Sprintf("%s[%d]", name, index)); err != nil {
-						return err
-					}
-				} else {
-					return fmt.Errorf("%s[%d] is not decimal but %s", name, index, reflect.TypeOf(rawValue))
+				if report.isDone() {
+					return
 				}
+
+				item.validateValue(rawValue, jsonPointer(name, index), report)
 			}
 
-			return nil
-		} else if value, floatOK := rawValue.(float64); floatOK {
-			return item.validateFloat(value, name)
-		} else if value, shopspringDecimalOK := rawValue.(decimal.Decimal); shopspringDecimalOK {
-			return item.validateShopSpringDecimal(value, name)
+			return
 		}
 
-		return fmt.Errorf("%s is not array decimal but %s", name, reflect.TypeOf(rawValue))
+		switch rawValue.(type) {
+		case float64, decimal.Decimal:
+		default:
+			report.Add(newValidationError(item, jsonPointer(name), CodeWrongType, "array decimal", rawValue,
+				"is not array decimal but %s", reflect.TypeOf(rawValue)))
+			return
+		}
 	}
 
+	item.validateValue(rawValue, jsonPointer(name), report)
+}
+
+func (item DxDecimal) validateValue(rawValue interface{}, path string, report *ValidationReport) {
 	if value, floatOK := rawValue.(float64); floatOK {
-		return item.validateFloat(value, name)
+		item.validateFloat(value, path, report)
 	} else if value, shopspringDecimalOK := rawValue.(decimal.Decimal); shopspringDecimalOK {
-		return item.validateShopSpringDecimal(value, name)
+		item.validateShopSpringDecimal(value, path, report)
+	} else {
+		report.Add(newValidationError(item, path, CodeWrongType, "decimal", rawValue,
+			"is not decimal but %s", reflect.TypeOf(rawValue)))
 	}
-
-	return fmt.Errorf("%s is not decimal but %s", name, reflect.TypeOf(rawValue))
 }
 
-func (item DxDecimal) validateFloat(value float64, name string) error {
+func (item DxDecimal) validateFloat(value float64, path string, report *ValidationReport) {
 	newValue := value
 
 	if item.Precision > 0 {
@@ -120,19 +146,90 @@ func (item DxDecimal) validateFloat(value float64, name string) error {
 	}
 
 	if _, residue := math.Modf(newValue); residue != 0 {
-		return fmt.Errorf("%s has invalid precision, expected %d: %f",
-			name, item.Precision, value)
+		report.Add(newValidationError(item, path, CodePrecision, item.Precision, value,
+			"has invalid precision, expected %d: %f", item.Precision, value))
 	}
 
-	return nil
+	item.numericRange().validateValue(item, decimal.NewFromFloat(value), value, path, report)
 }
 
-func (item DxDecimal) validateShopSpringDecimal(value decimal.Decimal, name string) error {
+func (item DxDecimal) validateShopSpringDecimal(value decimal.Decimal, path string, report *ValidationReport) {
 	precision := value.Exponent() * -1
 
 	if precision > int32(item.Precision) {
-		return fmt.Errorf("%s has invalid precision, expected %d: %s", name, item.Precision, value.String())
+		report.Add(newValidationError(item, path, CodePrecision, item.Precision, value,
+			"has invalid precision, expected %d: %s", item.Precision, value.String()))
 	}
 
-	return nil
+	item.numericRange().validateValue(item, value, value, path, report)
+}
+
+func (item DxDecimal) numericRange() numericRange {
+	return numericRange{enableMin: item.EnableMin, min: item.Min, exclusiveMin: item.ExclusiveMin,
+		enableMax: item.EnableMax, max: item.Max, exclusiveMax: item.ExclusiveMax}
+}
+
+//numericRange lower and upper limit shared by DxInt and DxDecimal,
+//limits are compared in shopspring decimal so large or fractional limit is exact
+type numericRange struct {
+	enableMin    bool
+	min          decimal.Decimal
+	exclusiveMin bool
+	enableMax    bool
+	max          decimal.Decimal
+	exclusiveMax bool
+}
+
+func (spec numericRange) xmlAttributes() string {
+	var result string
+
+	if spec.enableMin {
+		result += " min=\"" + spec.min.String() + "\""
+
+		if spec.exclusiveMin {
+			result += " exclusiveMin=\"true\""
+		}
+	}
+
+	if spec.enableMax {
+		result += " max=\"" + spec.max.String() + "\""
+
+		if spec.exclusiveMax {
+			result += " exclusiveMax=\"true\""
+		}
+	}
+
+	return result
+}
+
+//validateValue check value is within range, rawValue is the original input used as ValidationError.Actual
+func (spec numericRange) validateValue(item DxItem, value decimal.Decimal, rawValue interface{},
+	path string, report *ValidationReport) {
+	if spec.enableMin {
+		cmp := value.Cmp(spec.min)
+
+		if spec.exclusiveMin && cmp <= 0 {
+			report.Add(newValidationError(item, path, CodeMin, spec.min.String(), rawValue,
+				"is not greater than %s: %s", spec.min.String(), value.String()))
+		} else if cmp < 0 {
+			report.Add(newValidationError(item, path, CodeMin, spec.min.String(), rawValue,
+				"is less than %s: %s", spec.min.String(), value.String()))
+		}
+	}
+
+	if spec.enableMax {
+		cmp := value.Cmp(spec.max)
+
+		if spec.exclusiveMax && cmp >= 0 {
+			report.Add(newValidationError(item, path, CodeMax, spec.max.String(), rawValue,
+				"is not less than %s: %s", spec.max.String(), value.String()))
+		} else if cmp > 0 {
+			report.Add(newValidationError(item, path, CodeMax, spec.max.String(), rawValue,
+				"is greater than %s: %s", spec.max.String(), value.String()))
+		}
+	}
+}
+
+func (item DxDecimal) arraySpec() arraySpec {
+	return arraySpec{minItems: item.MinItems, maxItems: item.MaxItems, uniqueItems: item.UniqueItems}
 }
@@ -72,6 +72,32 @@ func TestDxDecimal_ValidateData(t *testing.T) {
 			args:    args{name: "koko", input: map[string]interface{}{"koko": []interface{}{decimal.NewFromFloat(12.354), decimal.NewFromFloat(0.078)}}},
 			wantErr: false,
 		},
+		{
+			name: "decimal inclusive range test",
+			item: DxDecimal{Name: "discount", Precision: 2,
+				EnableMin: true, Min: decimal.New(0, 0), EnableMax: true, Max: decimal.New(100, 0)},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": 100.0}},
+			wantErr: false,
+		},
+		{
+			name: "decimal exclusive range test",
+			item: DxDecimal{Name: "discount", Precision: 2,
+				EnableMin: true, Min: decimal.New(0, 0), EnableMax: true, Max: decimal.New(100, 0), ExclusiveMax: true},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": 100.0}},
+			wantErr: true,
+		},
+		{
+			name:    "decimal fractional range test",
+			item:    DxDecimal{Name: "rate", Precision: 2, EnableMin: true, Min: decimal.New(1, -2), ExclusiveMin: true},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": decimal.RequireFromString("0.01")}},
+			wantErr: true,
+		},
+		{
+			name:    "decimal array range test",
+			item:    DxDecimal{Name: "discount", IsArray: true, Precision: 2, EnableMax: true, Max: decimal.New(100, 0)},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": []float64{12.5, 100.01}}},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
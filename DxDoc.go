@@ -1,6 +1,8 @@
 package gxschema
 
 import (
+	"bytes"
+	"encoding/xml"
 	"fmt"
 	"strings"
 )
@@ -14,6 +16,8 @@ type DxDoc struct {
 	ID       string   //ID document unique identifier (suggest UUID)
 	Revision int      //Revision document revision, each changes of document structure revision value shall increament by 1
 	Items    []DxItem //Items document contents, each item represent single field of document
+	IsStrict bool     //IsStrict reject input key which is not declared in Items
+	Types    []DxType //Types named item groups which can be referenced by DxSection.TypeName
 }
 
 //XML generate document definition into XML format
@@ -22,6 +26,14 @@ func (doc DxDoc) XML() (string, error) {
 		"<?xml version=\"1.0\"?>\n<dxdoc name=\"%s\" revision=\"%d\" id=\"%s\">",
 		doc.Name, doc.Revision, doc.ID)
 
+	if doc.IsStrict {
+		result = strings.TrimSuffix(result, ">") + " additionalItems=\"false\">"
+	}
+
+	for _, dxtype := range doc.Types {
+		result += "\n" + dxtype.XML(1)
+	}
+
 	for _, item := range doc.Items {
 		result += "\n" + item.XML(1)
 	}
@@ -29,30 +41,53 @@ func (doc DxDoc) XML() (string, error) {
 	return result + "\n</dxdoc>", nil
 }
 
-//ValidateData check input data integration with present DxDoc definition instance
+//ValidateData check input data integration with present DxDoc definition instance,
+//stop at first violation found
 func (doc DxDoc) ValidateData(input map[string]interface{}) error {
+	report := &ValidationReport{failFast: true}
+	doc.collectErrors(input, report)
+
+	return report.firstError()
+}
+
+//ValidateAll check input data integration with present DxDoc definition instance,
+//every item (including each DxSection array element) is validated and all violations are reported
+func (doc DxDoc) ValidateAll(input map[string]interface{}) *ValidationReport {
+	report := &ValidationReport{}
+	doc.collectErrors(input, report)
+
+	return report
+}
+
+func (doc DxDoc) collectErrors(input map[string]interface{}, report *ValidationReport) {
 	checkMark := make([]int, len(doc.Items))
 
 	for key := range input {
+		if report.isDone() {
+			return
+		}
+
 		tmpIndex, tmpItem := doc.findItem(key)
 		if tmpItem == nil {
+			if doc.IsStrict {
+				report.Add(newValidationError(nil, jsonPointer(key), CodeUndeclared, nil, input[key],
+					"is not declared in %s", doc.Name))
+			}
+
 			continue
 		}
 
-		if err := tmpItem.ValidateData(input, key); err != nil {
-			return err
-		}
+		collectItemErrors(tmpItem, input, key, report)
 
 		checkMark[tmpIndex]++
 	}
 
-	for i := 0; i < len(doc.Items); i++ {
+	for i := 0; i < len(doc.Items) && !report.isDone(); i++ {
 		if checkMark[i] == 0 && !doc.Items[i].IsValueOptional() {
-			return fmt.Errorf("'%s' not found in %s", doc.Items[i].GetName(), doc.Name)
+			report.Add(newValidationError(doc.Items[i], jsonPointer(doc.Items[i].GetName()),
+				CodeMissing, nil, nil, "not found in %s", doc.Name))
 		}
 	}
-
-	return nil
 }
 
 func (doc DxDoc) findItem(name string) (int, DxItem) {
@@ -64,3 +99,11 @@ func (doc DxDoc) findItem(name string) (int, DxItem) {
 
 	return -1, nil
 }
+
+//escapeXMLAttribute escape special characters of XML attribute value
+func escapeXMLAttribute(value string) string {
+	var buffer bytes.Buffer
+	xml.EscapeText(&buffer, []byte(value))
+
+	return buffer.String()
+}
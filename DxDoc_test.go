@@ -1,6 +1,7 @@
 package gxschema
 
 import (
+	"sort"
 	"strings"
 	"testing"
 )
@@ -81,3 +82,104 @@ func TestDxDoc_ValidateData(t *testing.T) {
 		})
 	}
 }
+
+func TestDxDoc_ValidateAll(t *testing.T) {
+	doc := DxDoc{Name: "invoice", Revision: 2, Items: []DxItem{
+		DxStr{Name: "docNo", EnableLenLimit: true, LenLimit: 4},
+		DxDecimal{Name: "total", Precision: 2},
+		DxSection{Name: "items", IsArray: true, Items: []DxItem{
+			DxStr{Name: "description"},
+			DxInt{Name: "qty"},
+		}},
+	}}
+
+	input := map[string]interface{}{
+		"docNo": "abcde",
+		"items": []map[string]interface{}{
+			map[string]interface{}{"description": "dfgfghfh", "qty": 2.5},
+			map[string]interface{}{"qty": 2},
+			map[string]interface{}{"description": "nrrty erte", "qty": 1},
+		},
+	}
+
+	report := doc.ValidateAll(input)
+
+	if report.IsValid() {
+		t.Error("expect validation report has violations")
+		return
+	}
+
+	expected := []string{
+		"/docNo length",
+		"/items/0/qty wrong_type",
+		"/items/1/description missing",
+		"/total missing",
+	}
+
+	violations := make([]string, len(report.Errors))
+	for index, err := range report.Errors {
+		violations[index] = err.Path + " " + string(err.Code)
+	}
+	sort.Strings(violations)
+
+	if strings.Join(violations, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("violations not tally with [output]:\n%s\n\n[expected]:\n%s",
+			strings.Join(violations, "\n"), strings.Join(expected, "\n"))
+	}
+
+	if err := doc.ValidateData(input); err == nil {
+		t.Error("expect DxDoc.ValidateData() stop at first violation but pass")
+	}
+
+	input["docNo"] = "abcd"
+	input["total"] = 12.5
+	input["items"] = []interface{}{map[string]interface{}{"description": "dfgfghfh", "qty": 2}}
+
+	if report := doc.ValidateAll(input); !report.IsValid() {
+		t.Errorf("expect no violation but get:\n%s", report.Error())
+	}
+}
+
+func TestDxDoc_ValidateAll_strict(t *testing.T) {
+	doc := DxDoc{Name: "invoice", Revision: 2, IsStrict: true, Items: []DxItem{
+		DxStr{Name: "docNo"},
+		DxSection{Name: "items", IsArray: true, Items: []DxItem{
+			DxInt{Name: "qty"},
+		}},
+		DxSection{Name: "customer", IsStrict: true, Items: []DxItem{
+			DxStr{Name: "name"},
+		}},
+	}}
+
+	input := map[string]interface{}{
+		"docNo":    "abcd",
+		"remark":   "urgent",
+		"items":    []interface{}{map[string]interface{}{"qty": 2, "qyt": 3}},
+		"customer": map[string]interface{}{"name": "abc", "nmae": "abc"},
+	}
+
+	report := doc.ValidateAll(input)
+
+	expected := []string{
+		"/customer/nmae undeclared",
+		"/remark undeclared",
+	}
+
+	violations := make([]string, len(report.Errors))
+	for index, err := range report.Errors {
+		violations[index] = err.Path + " " + string(err.Code)
+	}
+	sort.Strings(violations)
+
+	if strings.Join(violations, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("violations not tally with [output]:\n%s\n\n[expected]:\n%s",
+			strings.Join(violations, "\n"), strings.Join(expected, "\n"))
+	}
+
+	doc.IsStrict = false
+	input["customer"] = map[string]interface{}{"name": "abc"}
+
+	if err := doc.ValidateData(input); err != nil {
+		t.Errorf("expect undeclared key is ignored when not strict but get: %s", err.Error())
+	}
+}
@@ -0,0 +1,178 @@
+package gxschema
+
+import (
+	"reflect"
+	"strings"
+)
+
+//DxOption single accepted value of DxEnum
+type DxOption struct {
+	Value string
+	Label string //Label human readable text of value, not used in validation
+}
+
+//DxEnum enumerated string data type, value must be one of Options
+type DxEnum struct {
+	Name        string
+	IsOptional  bool
+	IsArray     bool
+	MinItems    int  //MinItems least number of array elements, 0 means no limit
+	MaxItems    int  //MaxItems most number of array elements, 0 means no limit
+	UniqueItems bool //UniqueItems array elements must be distinct
+	IgnoreCase  bool //IgnoreCase compare value with option value case insensitively
+	Options     []DxOption
+}
+
+//GetName get name
+func (item DxEnum) GetName() string { return item.Name }
+
+//IsValueOptional is field value optional
+func (item DxEnum) IsValueOptional() bool { return item.IsOptional }
+
+//IsValueArray is field value allow to store multiple values
+func (item DxEnum) IsValueArray() bool { return item.IsArray }
+
+//XML generate XML
+func (item DxEnum) XML(indentLevel int) string {
+	var indent string
+	for i := 0; i < indentLevel; i++ {
+		indent += "\t"
+	}
+	result := indent + "<dxenum name=\"" + item.Name + "\""
+
+	if item.IsArray {
+		result += " isArray=\"true\""
+	}
+
+	if item.IsOptional {
+		result += " isOptional=\"true\""
+	}
+
+	result += item.arraySpec().xmlAttributes()
+
+	if item.IgnoreCase {
+		result += " ignoreCase=\"true\""
+	}
+
+	result += ">"
+
+	for _, option := range item.Options {
+		result += "\n" + indent + "\t<option value=\"" + escapeXMLAttribute(option.Value) + "\""
+
+		if option.Label != "" {
+			result += " label=\"" + escapeXMLAttribute(option.Label) + "\""
+		}
+
+		result += "></option>"
+	}
+
+	return result + "\n" + indent + "</dxenum>"
+}
+
+//ValidateData validate input data
+func (item DxEnum) ValidateData(input map[string]interface{}, name string) error {
+	return validateFirst(item, input, name)
+}
+
+//CollectErrors validate input data and add every violation into report
+func (item DxEnum) CollectErrors(input map[string]interface{}, name string, report *ValidationReport) {
+	item.arraySpec().collectErrors(item, input, name, report)
+
+	if report.isDone() {
+		return
+	}
+
+	rawValue, keyOK := input[name]
+
+	if !keyOK {
+		if !item.IsOptional {
+			report.Add(newValidationError(item, jsonPointer(name), CodeMissing, nil, nil, "is not exists"))
+		}
+
+		return
+	} else if rawValue == nil && item.IsOptional {
+		return
+	}
+
+	if item.IsArray {
+		if strArr, arrOK := rawValue.([]string); arrOK {
+			for index, tmp := range strArr {
+				if report.isDone() {
+					return
+				}
+
+				item.validateValue(tmp, jsonPointer(name, index), report)
+			}
+
+			return
+		}
+
+		if arrObj, arrObjOK := rawValue.([]interface{}); arrObjOK {
+			for index, tmp := range arrObj {
+				if report.isDone() {
+					return
+				}
+
+				tmpStr, OK := tmp.(string)
+				if !OK {
+					report.Add(newValidationError(item, jsonPointer(name, index), CodeWrongType, "string", tmp,
+						"is not string but %s", reflect.TypeOf(tmp)))
+					continue
+				}
+
+				item.validateValue(tmpStr, jsonPointer(name, index), report)
+			}
+
+			return
+		}
+
+		report.Add(newValidationError(item, jsonPointer(name), CodeWrongType, "array string", rawValue,
+			"is not array string but %s", reflect.TypeOf(rawValue)))
+		return
+	}
+
+	str, strOK := rawValue.(string)
+	if !strOK {
+		report.Add(newValidationError(item, jsonPointer(name), CodeWrongType, "string", rawValue,
+			"is not string but %s", reflect.TypeOf(rawValue)))
+		return
+	}
+
+	item.validateValue(str, jsonPointer(name), report)
+}
+
+func (item DxEnum) validateValue(value string, path string, report *ValidationReport) {
+	if item.findOption(value) >= 0 {
+		return
+	}
+
+	values := item.optionValues()
+
+	report.Add(newValidationError(item, path, CodeEnum, values, value,
+		"is not one of [%s]: %s", strings.Join(values, ", "), value))
+}
+
+//findOption get index of option which match value, return -1 if not found
+func (item DxEnum) findOption(value string) int {
+	for index, option := range item.Options {
+		if option.Value == value || (item.IgnoreCase && strings.EqualFold(option.Value, value)) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+//optionValues get all accepted values
+func (item DxEnum) optionValues() []string {
+	values := make([]string, len(item.Options))
+	for index, option := range item.Options {
+		values[index] = option.Value
+	}
+
+	return values
+}
+
+func (item DxEnum) arraySpec() arraySpec {
+	return arraySpec{minItems: item.MinItems, maxItems: item.MaxItems, uniqueItems: item.UniqueItems}
+}
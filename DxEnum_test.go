@@ -0,0 +1,92 @@
+package gxschema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDxEnum_ValidateData(t *testing.T) {
+	type args struct {
+		input map[string]interface{}
+		name  string
+	}
+	options := []DxOption{
+		{Value: "draft", Label: "Draft"},
+		{Value: "approved", Label: "Approved"},
+		{Value: "void", Label: "Void"},
+	}
+	tests := []struct {
+		name    string
+		item    DxEnum
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "simple enum test",
+			item:    DxEnum{Name: "status", Options: options},
+			args:    args{name: "status", input: map[string]interface{}{"status": "approved"}},
+			wantErr: false},
+		{
+			name:    "enum unknown value test",
+			item:    DxEnum{Name: "status", Options: options},
+			args:    args{name: "status", input: map[string]interface{}{"status": "paid"}},
+			wantErr: true},
+		{
+			name:    "enum case sensitive test",
+			item:    DxEnum{Name: "status", Options: options},
+			args:    args{name: "status", input: map[string]interface{}{"status": "Draft"}},
+			wantErr: true},
+		{
+			name:    "enum ignore case test",
+			item:    DxEnum{Name: "status", IgnoreCase: true, Options: options},
+			args:    args{name: "status", input: map[string]interface{}{"status": "Draft"}},
+			wantErr: false},
+		{
+			name:    "enum not string test",
+			item:    DxEnum{Name: "status", Options: options},
+			args:    args{name: "status", input: map[string]interface{}{"status": 1}},
+			wantErr: true},
+		{
+			name:    "enum multi select test",
+			item:    DxEnum{Name: "status", IsArray: true, Options: options},
+			args:    args{name: "status", input: map[string]interface{}{"status": []interface{}{"draft", "void"}}},
+			wantErr: false},
+		{
+			name:    "enum multi select unknown value test",
+			item:    DxEnum{Name: "status", IsArray: true, Options: options},
+			args:    args{name: "status", input: map[string]interface{}{"status": []string{"draft", "paid"}}},
+			wantErr: true},
+		{
+			name:    "optional enum test",
+			item:    DxEnum{Name: "status", IsOptional: true, Options: options},
+			args:    args{name: "status", input: map[string]interface{}{}},
+			wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.item.ValidateData(tt.args.input, tt.args.name); (err != nil) != tt.wantErr {
+				t.Errorf("DxEnum.ValidateData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDxEnum_ValidateData_allowedValues(t *testing.T) {
+	item := DxEnum{Name: "status", Options: []DxOption{{Value: "draft"}, {Value: "approved"}, {Value: "void"}}}
+
+	err := item.ValidateData(map[string]interface{}{"status": "paid"}, "status")
+
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Errorf("expect *ValidationError but get %v", err)
+		return
+	}
+
+	if validationErr.Code != CodeEnum {
+		t.Errorf("expect error code %s but get %s", CodeEnum, validationErr.Code)
+	}
+
+	if !strings.Contains(validationErr.Error(), "[draft, approved, void]") {
+		t.Errorf("expect error message list allowed values but get: %s", validationErr.Error())
+	}
+}
@@ -1,7 +1,6 @@
 package gxschema
 
 import (
-	"fmt"
 	"reflect"
 )
 
@@ -10,9 +9,12 @@ import (
 //		1. file path
 //		2. file name
 type DxFile struct {
-	Name       string
-	IsOptional bool
-	IsArray    bool
+	Name        string
+	IsOptional  bool
+	IsArray     bool
+	MinItems    int  //MinItems least number of array elements, 0 means no limit
+	MaxItems    int  //MaxItems most number of array elements, 0 means no limit
+	UniqueItems bool //UniqueItems array elements must be distinct
 }
 
 //GetName get name
@@ -40,102 +42,136 @@ func (item DxFile) XML(indentLevel int) string {
 		result += " isOptional=\"true\""
 	}
 
+	result += item.arraySpec().xmlAttributes()
+
 	return result + "></dxfile>"
 }
 
 //ValidateData validate input data
 func (item DxFile) ValidateData(input map[string]interface{}, name string) error {
+	return validateFirst(item, input, name)
+}
+
+//CollectErrors validate input data and add every violation into report
+func (item DxFile) CollectErrors(input map[string]interface{}, name string, report *ValidationReport) {
+	item.arraySpec().collectErrors(item, input, name, report)
+
+	if report.isDone() {
+		return
+	}
+
 	rawValue, keyOK := input[name]
 
 	if !keyOK {
 		if !item.IsOptional {
-			return fmt.Errorf("map entry '%s' is not exists", name)
+			report.Add(newValidationError(item, jsonPointer(name), CodeMissing, nil, nil, "is not exists"))
 		}
 
-		return nil
+		return
 	} else if rawValue == nil && item.IsOptional {
-		return nil
+		return
 	}
 
 	if item.IsArray {
 		arrMap, arrOK := rawValue.([]map[string]interface{})
 		if arrOK {
 			for index, tmpMap := range arrMap {
-				if err := item.validateNode(tmpMap, fmt.Sprintf("%s[%d]", name, index)); err != nil {
-					return err
+				if report.isDone() {
+					return
 				}
+
+				item.validateNode(tmpMap, jsonPointer(name, index), report)
 			}
 
-			return nil
+			return
 		}
 
 		arrMapStr, arrMapStrOK := rawValue.([]map[string]string)
 		if arrMapStrOK {
 			for index, tmpMap := range arrMapStr {
-				if err := item.validateNodeV2(tmpMap, fmt.Sprintf("%s[%d]", name, index)); err != nil {
-					return err
+				if report.isDone() {
+					return
 				}
+
+				item.validateNodeV2(tmpMap, jsonPointer(name, index), report)
 			}
 
-			return nil
+			return
+		} else if arrObj, arrObjOK := rawValue.([]interface{}); arrObjOK {
+			for index, tmp := range arrObj {
+				if report.isDone() {
+					return
+				}
+
+				item.validateObject(tmp, jsonPointer(name, index), report)
+			}
+
+			return
 		} else if interfaceMap, interfaceMapOK := rawValue.(map[string]interface{}); interfaceMapOK {
-			return item.validateNode(interfaceMap, name)
+			item.validateNode(interfaceMap, jsonPointer(name), report)
+			return
 		} else if strMap, strMapOK := rawValue.(map[string]string); strMapOK {
-			return item.validateNodeV2(strMap, name)
+			item.validateNodeV2(strMap, jsonPointer(name), report)
+			return
 		}
 
-		return fmt.Errorf("%s is not array map but %s", name, reflect.TypeOf(rawValue))
+		report.Add(newValidationError(item, jsonPointer(name), CodeWrongType, "array map", rawValue,
+			"is not array map but %s", reflect.TypeOf(rawValue)))
+		return
 	}
 
+	item.validateObject(rawValue, jsonPointer(name), report)
+}
+
+func (item DxFile) validateObject(rawValue interface{}, path string, report *ValidationReport) {
 	if interfaceMap, interfaceMapOK := rawValue.(map[string]interface{}); interfaceMapOK {
-		return item.validateNode(interfaceMap, name)
+		item.validateNode(interfaceMap, path, report)
 	} else if strMap, strMapOK := rawValue.(map[string]string); strMapOK {
-		return item.validateNodeV2(strMap, name)
+		item.validateNodeV2(strMap, path, report)
+	} else {
+		report.Add(newValidationError(item, path, CodeWrongType, "map", rawValue,
+			"is not map but %s", reflect.TypeOf(rawValue)))
 	}
-
-	return fmt.Errorf("%s is not array map but %s", name, reflect.TypeOf(rawValue))
 }
 
-func (item DxFile) validateNode(tmpMap map[string]interface{}, name string) error {
+func (item DxFile) validateNode(tmpMap map[string]interface{}, path string, report *ValidationReport) {
 	//filename node
 	filenameRaw, filenameOK := tmpMap["filename"]
 	if !filenameOK {
-		return fmt.Errorf("%s has no 'filename' node", name)
-	}
-
-	_, OK := filenameRaw.(string)
-	if !OK {
-		return fmt.Errorf("%s['filename'] value is not string: %s",
-			name, reflect.TypeOf(filenameRaw))
+		report.Add(newValidationError(item, path+jsonPointer("filename"), CodeMissing, nil, nil,
+			"is not exists"))
+	} else if _, OK := filenameRaw.(string); !OK {
+		report.Add(newValidationError(item, path+jsonPointer("filename"), CodeWrongType, "string", filenameRaw,
+			"value is not string: %s", reflect.TypeOf(filenameRaw)))
 	}
 
 	//filepath node
 	filepathRaw, filepathOK := tmpMap["filepath"]
 	if !filepathOK {
-		return fmt.Errorf("%s has no 'filepath' node", name)
-	}
-
-	_, OK = filepathRaw.(string)
-	if !OK {
-		return fmt.Errorf("%s['filepath'] value is not string: %s",
-			name, reflect.TypeOf(filepathRaw))
+		report.Add(newValidationError(item, path+jsonPointer("filepath"), CodeMissing, nil, nil,
+			"is not exists"))
+	} else if _, OK := filepathRaw.(string); !OK {
+		report.Add(newValidationError(item, path+jsonPointer("filepath"), CodeWrongType, "string", filepathRaw,
+			"value is not string: %s", reflect.TypeOf(filepathRaw)))
 	}
-
-	return nil
 }
 
-func (item DxFile) validateNodeV2(tmpMap map[string]string, name string) error {
+func (item DxFile) validateNodeV2(tmpMap map[string]string, path string, report *ValidationReport) {
 	//filename node
 	_, filenameOK := tmpMap["filename"]
 	if !filenameOK {
-		return fmt.Errorf("%s has no 'filename' node", name)
+		report.Add(newValidationError(item, path+jsonPointer("filename"), CodeMissing, nil, nil,
+			"is not exists"))
 	}
 
 	//filepath node
 	_, filepathOK := tmpMap["filepath"]
 	if !filepathOK {
-		return fmt.Errorf("%s has no 'filepath' node", name)
+		report.Add(newValidationError(item, path+jsonPointer("filepath"), CodeMissing, nil, nil,
+			"is not exists"))
 	}
+}
 
-	return nil
+func (item DxFile) arraySpec() arraySpec {
+	return arraySpec{minItems: item.MinItems, maxItems: item.MaxItems, uniqueItems: item.UniqueItems}
 }
@@ -1,16 +1,26 @@
 package gxschema
 
 import (
-	"fmt"
 	"math"
 	"reflect"
+
+	"github.com/shopspring/decimal"
 )
 
 //DxInt integer data item
 type DxInt struct {
-	Name       string
-	IsOptional bool
-	IsArray    bool
+	Name         string
+	IsOptional   bool
+	IsArray      bool
+	MinItems     int  //MinItems least number of array elements, 0 means no limit
+	MaxItems     int  //MaxItems most number of array elements, 0 means no limit
+	UniqueItems  bool //UniqueItems array elements must be distinct
+	EnableMin    bool //EnableMin value must not less than Min
+	Min          decimal.Decimal
+	ExclusiveMin bool //ExclusiveMin value must greater than Min
+	EnableMax    bool //EnableMax value must not greater than Max
+	Max          decimal.Decimal
+	ExclusiveMax bool //ExclusiveMax value must less than Max
 }
 
 //GetName get name
@@ -38,78 +48,109 @@ func (item DxInt) XML(indentLevel int) string {
 		result += " isOptional=\"true\""
 	}
 
-	return result + "></dxint>"
+	result += item.arraySpec().xmlAttributes()
+
+	return result + item.numericRange().xmlAttributes() + "></dxint>"
 }
 
 //ValidateData validate input data
 func (item DxInt) ValidateData(input map[string]interface{}, name string) error {
+	return validateFirst(item, input, name)
+}
+
+//CollectErrors validate input data and add every violation into report
+func (item DxInt) CollectErrors(input map[string]interface{}, name string, report *ValidationReport) {
+	item.arraySpec().collectErrors(item, input, name, report)
+
+	if report.isDone() {
+		return
+	}
+
 	rawValue, keyOK := input[name]
 
 	if !keyOK {
 		if !item.IsOptional {
-			return fmt.Errorf("map entry '%s' is not exists", name)
+			report.Add(newValidationError(item, jsonPointer(name), CodeMissing, nil, nil, "is not exists"))
 		}
 
-		return nil
+		return
 	} else if rawValue == nil && item.IsOptional {
-		return nil
+		return
 	}
 
 	if item.IsArray {
-		_, arrOK := rawValue.([]int)
-		if arrOK {
-			return nil
-		}
+		if arrInt, arrOK := rawValue.([]int); arrOK {
+			for index, tmp := range arrInt {
+				if report.isDone() {
+					return
+				}
+
+				item.validateValue(tmp, jsonPointer(name, index), report)
+			}
 
-		if arrFloat, arrFloatOK := rawValue.([]float64); arrFloatOK {
+			return
+		} else if arrFloat, arrFloatOK := rawValue.([]float64); arrFloatOK {
 			for index, tmp := range arrFloat {
-				if _, x2 := math.Modf(tmp); x2 != 0 {
-					return fmt.Errorf("%s[%d] is not int value: %f", name, index, tmp)
+				if report.isDone() {
+					return
 				}
+
+				item.validateValue(tmp, jsonPointer(name, index), report)
 			}
-			return nil
+
+			return
 		} else if arrObj, arrObjOK := rawValue.([]interface{}); arrObjOK {
 			for index, tmp := range arrObj {
-				if _, ok := tmp.(int); ok {
-					continue
+				if report.isDone() {
+					return
 				}
 
-				if tmpf, ok := tmp.(float64); ok {
-					if _, x2 := math.Modf(tmpf); x2 == 0 {
-						continue
-					}
-
-					return fmt.Errorf("%s[%d] is not int value: %f", name, index, tmpf)
-				}
-
-				return fmt.Errorf("%s[%d] is not int value: %s", name, index, tmp)
+				item.validateValue(tmp, jsonPointer(name, index), report)
 			}
 
-			return nil
-		} else if _, intOK := rawValue.(int); intOK {
-			return nil
-		} else if tmpFloat, floatOK := rawValue.(float64); floatOK {
-			_, x2 := math.Modf(tmpFloat)
-			if x2 == 0 {
-				return nil
-			}
-
-			return fmt.Errorf("%s is not int value: %f", name, x2)
+			return
 		}
 
-		return fmt.Errorf("input value is not array int but %s", reflect.TypeOf(rawValue))
+		switch rawValue.(type) {
+		case int, float64:
+		default:
+			report.Add(newValidationError(item, jsonPointer(name), CodeWrongType, "array int", rawValue,
+				"is not array int but %s", reflect.TypeOf(rawValue)))
+			return
+		}
 	}
 
-	if _, intOK := rawValue.(int); intOK {
-		return nil
-	} else if tmpFloat, floatOK := rawValue.(float64); floatOK {
-		_, x2 := math.Modf(tmpFloat)
-		if x2 == 0 {
-			return nil
+	item.validateValue(rawValue, jsonPointer(name), report)
+}
+
+func (item DxInt) validateValue(rawValue interface{}, path string, report *ValidationReport) {
+	var value decimal.Decimal
+
+	switch tmp := rawValue.(type) {
+	case int:
+		value = decimal.NewFromInt(int64(tmp))
+	case float64:
+		if _, x2 := math.Modf(tmp); x2 != 0 {
+			report.Add(newValidationError(item, path, CodeWrongType, "int", tmp,
+				"is not int value: %v", tmp))
+			return
 		}
 
-		return fmt.Errorf("%s is not int value: %f", name, x2)
+		value = decimal.NewFromFloat(tmp)
+	default:
+		report.Add(newValidationError(item, path, CodeWrongType, "int", rawValue,
+			"is not int but %s", reflect.TypeOf(rawValue)))
+		return
 	}
 
-	return fmt.Errorf("input value is not int but %s", reflect.TypeOf(rawValue))
+	item.numericRange().validateValue(item, value, rawValue, path, report)
+}
+
+func (item DxInt) numericRange() numericRange {
+	return numericRange{enableMin: item.EnableMin, min: item.Min, exclusiveMin: item.ExclusiveMin,
+		enableMax: item.EnableMax, max: item.Max, exclusiveMax: item.ExclusiveMax}
+}
+
+func (item DxInt) arraySpec() arraySpec {
+	return arraySpec{minItems: item.MinItems, maxItems: item.MaxItems, uniqueItems: item.UniqueItems}
 }
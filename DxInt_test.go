@@ -1,6 +1,10 @@
 package gxschema
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
 
 func TestDxInt_ValidateData(t *testing.T) {
 	intDef := DxInt{Name: "qty", IsOptional: false, IsArray: false}
@@ -30,3 +34,64 @@ func TestDxInt_ValidateData(t *testing.T) {
 		t.Errorf("soso: expect fail but pass")
 	}
 }
+
+func TestDxInt_ValidateData_constraints(t *testing.T) {
+	type args struct {
+		input map[string]interface{}
+		name  string
+	}
+	tests := []struct {
+		name    string
+		item    DxInt
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "positive int test",
+			item:    DxInt{Name: "qty", EnableMin: true, Min: decimal.New(0, 0), ExclusiveMin: true},
+			args:    args{name: "qty", input: map[string]interface{}{"qty": 1}},
+			wantErr: false},
+		{
+			name:    "positive int zero test",
+			item:    DxInt{Name: "qty", EnableMin: true, Min: decimal.New(0, 0), ExclusiveMin: true},
+			args:    args{name: "qty", input: map[string]interface{}{"qty": 0}},
+			wantErr: true},
+		{
+			name:    "int inclusive max test",
+			item:    DxInt{Name: "qty", EnableMax: true, Max: decimal.New(100, 0)},
+			args:    args{name: "qty", input: map[string]interface{}{"qty": float64(100)}},
+			wantErr: false},
+		{
+			name:    "int large max test",
+			item:    DxInt{Name: "qty", EnableMax: true, Max: decimal.RequireFromString("9007199254740993")},
+			args:    args{name: "qty", input: map[string]interface{}{"qty": 9007199254740994}},
+			wantErr: true},
+		{
+			name:    "int array range test",
+			item:    DxInt{Name: "qty", IsArray: true, EnableMin: true, Min: decimal.New(1, 0)},
+			args:    args{name: "qty", input: map[string]interface{}{"qty": []int{3, 1, 0}}},
+			wantErr: true},
+		{
+			name:    "int interface array range test",
+			item:    DxInt{Name: "qty", IsArray: true, EnableMin: true, Min: decimal.New(1, 0)},
+			args:    args{name: "qty", input: map[string]interface{}{"qty": []interface{}{3, float64(1)}}},
+			wantErr: false},
+		{
+			name:    "int array unique test",
+			item:    DxInt{Name: "qty", IsArray: true, UniqueItems: true},
+			args:    args{name: "qty", input: map[string]interface{}{"qty": []interface{}{3, float64(3)}}},
+			wantErr: true},
+		{
+			name:    "int array min items test",
+			item:    DxInt{Name: "qty", IsArray: true, MinItems: 2},
+			args:    args{name: "qty", input: map[string]interface{}{"qty": 3}},
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.item.ValidateData(tt.args.input, tt.args.name); (err != nil) != tt.wantErr {
+				t.Errorf("DxInt.ValidateData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
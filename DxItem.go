@@ -1,5 +1,14 @@
 package gxschema
 
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
 //DxItem document item's interface
 type DxItem interface {
 	GetName() string                                              //GetName get item's name
@@ -8,3 +17,167 @@ type DxItem interface {
 	IsValueOptional() bool                                        //IsValueOptional is value optional
 	IsValueArray() bool                                           //IsValueArray is the item allow to store more than 1 record
 }
+
+//ErrorCollector optional interface of DxItem which reports every violation instead of the first one;
+//violation of item which doesn't implement it is the error returned by ValidateData
+type ErrorCollector interface {
+	CollectErrors(input map[string]interface{}, name string, report *ValidationReport) //CollectErrors check input data and add every violation into report
+}
+
+//dereferenceItem turn pointer item (e.g. *DxStr returned by schema parser) into its value
+func dereferenceItem(item DxItem) DxItem {
+	value := reflect.ValueOf(item)
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		if tmp, ok := value.Elem().Interface().(DxItem); ok {
+			return tmp
+		}
+	}
+
+	return item
+}
+
+//arraySpec element count and uniqueness constraints of array item, shared by every item type
+type arraySpec struct {
+	minItems    int      //minItems least number of elements, 0 means no limit
+	maxItems    int      //maxItems most number of elements, 0 means no limit
+	uniqueItems bool     //uniqueItems elements must be distinct
+	uniqueBy    []string //uniqueBy elements must be distinct by these child values, dxsection only
+}
+
+//arrayConstrained item which declares array constraints
+type arrayConstrained interface {
+	arraySpec() arraySpec
+}
+
+//itemArraySpec get array constraints of item, empty constraints if item declares none
+func itemArraySpec(item DxItem) arraySpec {
+	if tmp, ok := dereferenceItem(item).(arrayConstrained); ok {
+		return tmp.arraySpec()
+	}
+
+	return arraySpec{}
+}
+
+func (spec arraySpec) xmlAttributes() string {
+	var result string
+
+	if spec.minItems > 0 {
+		result += fmt.Sprintf(" minItems=\"%d\"", spec.minItems)
+	}
+
+	if spec.maxItems > 0 {
+		result += fmt.Sprintf(" maxItems=\"%d\"", spec.maxItems)
+	}
+
+	if spec.uniqueItems {
+		result += " uniqueItems=\"true\""
+	}
+
+	if len(spec.uniqueBy) > 0 {
+		result += " uniqueBy=\"" + escapeXMLAttribute(strings.Join(spec.uniqueBy, ",")) + "\""
+	}
+
+	return result
+}
+
+//collectErrors check element count and uniqueness of array value;
+//element type is validated by item itself, single value is counted as one element
+func (spec arraySpec) collectErrors(item DxItem, input map[string]interface{}, name string,
+	report *ValidationReport) {
+	rawValue, keyOK := input[name]
+	if !item.IsValueArray() || !keyOK || rawValue == nil {
+		return
+	}
+
+	var elements []interface{}
+
+	value := reflect.ValueOf(rawValue)
+	if value.Kind() == reflect.Slice {
+		for i := 0; i < value.Len(); i++ {
+			elements = append(elements, value.Index(i).Interface())
+		}
+	} else {
+		elements = append(elements, rawValue)
+	}
+
+	if spec.minItems > 0 && len(elements) < spec.minItems {
+		report.Add(newValidationError(item, jsonPointer(name), CodeMinItems, spec.minItems, len(elements),
+			"has %d element(s), expected at least %d", len(elements), spec.minItems))
+	}
+
+	if spec.maxItems > 0 && len(elements) > spec.maxItems {
+		report.Add(newValidationError(item, jsonPointer(name), CodeMaxItems, spec.maxItems, len(elements),
+			"has %d element(s), expected at most %d", len(elements), spec.maxItems))
+	}
+
+	if !spec.uniqueItems && len(spec.uniqueBy) == 0 {
+		return
+	}
+
+	seen := make(map[string]int)
+
+	for index, element := range elements {
+		if report.isDone() {
+			return
+		}
+
+		key, keyOK := spec.uniqueKey(element)
+		if !keyOK {
+			continue
+		}
+
+		if first, found := seen[key]; found {
+			if len(spec.uniqueBy) > 0 {
+				report.Add(newValidationError(item, jsonPointer(name, index), CodeUnique, nil, element,
+					"duplicates element %d by %s", first, strings.Join(spec.uniqueBy, ",")))
+			} else {
+				report.Add(newValidationError(item, jsonPointer(name, index), CodeUnique, nil, element,
+					"duplicates element %d", first))
+			}
+
+			continue
+		}
+
+		seen[key] = index
+	}
+}
+
+//uniqueKey generate comparison key of array element, element missing any uniqueBy value is skipped
+func (spec arraySpec) uniqueKey(element interface{}) (string, bool) {
+	if len(spec.uniqueBy) == 0 {
+		return uniqueValueKey(element), true
+	}
+
+	tmpMap, mapOK := element.(map[string]interface{})
+	if !mapOK {
+		return "", false
+	}
+
+	var keys []string
+	for _, field := range spec.uniqueBy {
+		value, ok := tmpMap[field]
+		if !ok || value == nil {
+			return "", false
+		}
+
+		keys = append(keys, uniqueValueKey(value))
+	}
+
+	return strings.Join(keys, "\x00"), true
+}
+
+//uniqueValueKey generate comparison key of single value, numbers are equal regardless of GO type
+func uniqueValueKey(value interface{}) string {
+	switch tmp := value.(type) {
+	case int:
+		return "number:" + decimal.NewFromInt(int64(tmp)).String()
+	case float64:
+		return "number:" + decimal.NewFromFloat(tmp).String()
+	case decimal.Decimal:
+		return "number:" + tmp.String()
+	case time.Time:
+		return "time:" + tmp.UTC().Format(time.RFC3339Nano)
+	}
+
+	return fmt.Sprintf("%T:%v", value, value)
+}
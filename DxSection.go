@@ -1,16 +1,21 @@
 package gxschema
 
 import (
-	"fmt"
 	"strings"
 )
 
 //DxSection group DxItem(s) data type
 type DxSection struct {
-	Name       string
-	IsOptional bool
-	IsArray    bool
-	Items      []DxItem
+	Name        string
+	IsOptional  bool
+	IsArray     bool
+	MinItems    int      //MinItems least number of array elements, 0 means no limit
+	MaxItems    int      //MaxItems most number of array elements, 0 means no limit
+	UniqueItems bool     //UniqueItems array elements must be distinct
+	UniqueBy    []string //UniqueBy array elements must be distinct by these child values
+	Items       []DxItem
+	IsStrict    bool   //IsStrict reject input key which is not declared in Items
+	TypeName    string //TypeName name of DxType which Items are copied from, XML refers to type instead of listing Items
 }
 
 //GetName get name
@@ -26,7 +31,7 @@ func (item DxSection) IsValueArray() bool { return item.IsArray }
 func (item DxSection) XML(indentLevel int) string {
 	var indent string
 	for i := 0; i < indentLevel; i++ {
-		indent = "\t"
+		indent += "\t"
 	}
 	result := indent + "<dxsection name=\"" + item.Name + "\""
 
@@ -38,6 +43,16 @@ func (item DxSection) XML(indentLevel int) string {
 		result += " isOptional=\"true\""
 	}
 
+	result += item.arraySpec().xmlAttributes()
+
+	if item.IsStrict {
+		result += " additionalItems=\"false\""
+	}
+
+	if item.TypeName != "" {
+		return result + " type=\"" + item.TypeName + "\"></dxsection>"
+	}
+
 	result += ">"
 
 	for _, item := range item.Items {
@@ -49,67 +64,101 @@ func (item DxSection) XML(indentLevel int) string {
 
 //ValidateData validate input data
 func (item DxSection) ValidateData(input map[string]interface{}, name string) error {
+	return validateFirst(item, input, name)
+}
+
+//CollectErrors validate input data and add every violation into report,
+//every element is validated when section is an array
+func (item DxSection) CollectErrors(input map[string]interface{}, name string, report *ValidationReport) {
+	item.arraySpec().collectErrors(item, input, name, report)
+
+	if report.isDone() {
+		return
+	}
+
 	rawValue, keyOK := input[name]
 
 	if !keyOK {
 		if !item.IsOptional {
-			return fmt.Errorf("map entry '%s' is not exists", name)
+			report.Add(newValidationError(item, jsonPointer(name), CodeMissing, nil, nil, "is not exists"))
 		}
 
-		return nil
+		return
 	} else if rawValue == nil && item.IsOptional {
-		return nil
+		return
 	}
 
 	if item.IsArray {
-		subArr, subOK := rawValue.([]map[string]interface{})
-		if !subOK {
-			return fmt.Errorf("%s is not map array", name)
-		}
+		if subArr, subOK := rawValue.([]map[string]interface{}); subOK {
+			//iterate each array item and validate its value
+			for index, tmp := range subArr {
+				if report.isDone() {
+					return
+				}
+
+				item.validateItem(tmp, jsonPointer(name, index), report)
+			}
+
+			return
+		} else if subArr, subOK := rawValue.([]interface{}); subOK {
+			for index, tmp := range subArr {
+				if report.isDone() {
+					return
+				}
 
-		//iterate each array item and validate its value
-		for index, tmp := range subArr {
-			if err := item.validateItem(tmp, fmt.Sprintf("%s[%d]", name, index)); err != nil {
-				return err
+				item.validateItem(tmp, jsonPointer(name, index), report)
 			}
+
+			return
 		}
 
-		return nil
+		report.Add(newValidationError(item, jsonPointer(name), CodeWrongType, "map array", rawValue,
+			"is not map array"))
+		return
 	}
 
-	return item.validateItem(rawValue, name)
+	item.validateItem(rawValue, jsonPointer(name), report)
 }
 
-func (item DxSection) validateItem(rawValue interface{}, name string) error {
+func (item DxSection) validateItem(rawValue interface{}, path string, report *ValidationReport) {
 	subItem, subOK := rawValue.(map[string]interface{})
 	if !subOK {
-		return fmt.Errorf("%s is not map", name)
+		report.Add(newValidationError(item, path, CodeWrongType, "map", rawValue, "is not map"))
+		return
 	}
 
 	//build checkmark
 	checkMark := make([]int, len(item.Items))
+	subReport := &ValidationReport{failFast: report.failFast}
 
 	for key := range subItem {
+		if subReport.isDone() {
+			break
+		}
+
 		defIndex, def := item.findItem(key)
 		if def == nil {
+			if item.IsStrict {
+				subReport.Add(newValidationError(nil, jsonPointer(key), CodeUndeclared, nil, subItem[key],
+					"is not declared in %s", item.Name))
+			}
+
 			continue
 		}
 
-		validErr := def.ValidateData(subItem, key)
-		if validErr != nil {
-			return validErr
-		}
+		collectItemErrors(def, subItem, key, subReport)
 
 		checkMark[defIndex]++
 	}
 
-	for i := 0; i < len(item.Items); i++ {
+	for i := 0; i < len(item.Items) && !subReport.isDone(); i++ {
 		if checkMark[i] == 0 && !item.Items[i].IsValueOptional() {
-			return fmt.Errorf("%s has no key '%s'", name, item.Items[i].GetName())
+			subReport.Add(newValidationError(item.Items[i], jsonPointer(item.Items[i].GetName()),
+				CodeMissing, nil, nil, "is not exists"))
 		}
 	}
 
-	return nil
+	report.addNested(path, subReport)
 }
 
 func (item DxSection) findItem(name string) (int, DxItem) {
@@ -121,3 +170,8 @@ func (item DxSection) findItem(name string) (int, DxItem) {
 
 	return -1, nil
 }
+
+func (item DxSection) arraySpec() arraySpec {
+	return arraySpec{minItems: item.MinItems, maxItems: item.MaxItems, uniqueItems: item.UniqueItems,
+		uniqueBy: item.UniqueBy}
+}
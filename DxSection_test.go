@@ -1,6 +1,9 @@
 package gxschema
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestDxSection_ValidateData(t *testing.T) {
 	type args struct {
@@ -67,3 +70,52 @@ func TestDxSection_ValidateData(t *testing.T) {
 		})
 	}
 }
+
+func TestDxSection_CollectErrors_arrayConstraints(t *testing.T) {
+	item := DxSection{Name: "items", IsArray: true, MinItems: 1, MaxItems: 2, UniqueBy: []string{"sku"}, Items: []DxItem{
+		DxStr{Name: "sku"},
+		DxInt{Name: "qty"},
+	}}
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  []string
+	}{
+		{
+			name:  "valid line items",
+			value: []interface{}{map[string]interface{}{"sku": "A1", "qty": 1}, map[string]interface{}{"sku": "B2", "qty": 1}},
+			want:  nil,
+		},
+		{
+			name:  "no line item",
+			value: []interface{}{},
+			want:  []string{"/items min_items"},
+		},
+		{
+			name: "duplicate sku and too many line items",
+			value: []map[string]interface{}{
+				map[string]interface{}{"sku": "A1", "qty": 1},
+				map[string]interface{}{"sku": "B2", "qty": 2},
+				map[string]interface{}{"sku": "A1", "qty": 3},
+			},
+			want: []string{"/items max_items", "/items/2 unique"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &ValidationReport{}
+			item.CollectErrors(map[string]interface{}{"items": tt.value}, "items", report)
+
+			var got []string
+			for _, err := range report.Errors {
+				got = append(got, err.Path+" "+string(err.Code))
+			}
+
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("violations not tally with [output]:\n%v\n\n[expected]:\n%v", got, tt.want)
+			}
+		})
+	}
+}
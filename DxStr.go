@@ -3,6 +3,19 @@ package gxschema
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+//LenUnit unit used to measure string length
+type LenUnit string
+
+const (
+	LenUnitByte     LenUnit = "byte"     //LenUnitByte count bytes of UTF-8 encoded string, default unit
+	LenUnitRune     LenUnit = "rune"     //LenUnitRune count unicode code points
+	LenUnitGrapheme LenUnit = "grapheme" //LenUnitGrapheme count user perceived characters (grapheme clusters)
 )
 
 //DxStr string data type
@@ -10,8 +23,17 @@ type DxStr struct {
 	Name           string
 	IsOptional     bool
 	IsArray        bool
-	EnableLenLimit bool
+	MinItems       int  //MinItems least number of array elements, 0 means no limit
+	MaxItems       int  //MaxItems most number of array elements, 0 means no limit
+	UniqueItems    bool //UniqueItems array elements must be distinct
+	EnableLenLimit bool //EnableLenLimit value length must be exactly LenLimit
 	LenLimit       int
+	EnableMinLen   bool //EnableMinLen value length must not shorter than MinLen
+	MinLen         int
+	EnableMaxLen   bool //EnableMaxLen value length must not longer than MaxLen
+	MaxLen         int
+	LenUnit        LenUnit        //LenUnit unit of LenLimit, MinLen and MaxLen; empty value is LenUnitByte
+	Pattern        *regexp.Regexp //Pattern value must match regular expression, use ^ and $ to match whole value
 }
 
 //GetName get name
@@ -39,72 +61,168 @@ func (item DxStr) XML(indentLevel int) string {
 		result += " isOptional=\"true\""
 	}
 
+	result += item.arraySpec().xmlAttributes()
+
 	if item.EnableLenLimit {
 		result += fmt.Sprintf(" lenLimit=\"%d\"", item.LenLimit)
 	}
 
+	if item.EnableMinLen {
+		result += fmt.Sprintf(" minLen=\"%d\"", item.MinLen)
+	}
+
+	if item.EnableMaxLen {
+		result += fmt.Sprintf(" maxLen=\"%d\"", item.MaxLen)
+	}
+
+	if item.LenUnit != "" {
+		result += " lenUnit=\"" + string(item.LenUnit) + "\""
+	}
+
+	if item.Pattern != nil {
+		result += " pattern=\"" + escapeXMLAttribute(item.Pattern.String()) + "\""
+	}
+
 	return result + "></dxstr>"
 }
 
 //ValidateData validate input data
 func (item DxStr) ValidateData(input map[string]interface{}, name string) error {
+	return validateFirst(item, input, name)
+}
+
+//CollectErrors validate input data and add every violation into report
+func (item DxStr) CollectErrors(input map[string]interface{}, name string, report *ValidationReport) {
+	item.arraySpec().collectErrors(item, input, name, report)
+
+	if report.isDone() {
+		return
+	}
+
 	rawValue, keyOK := input[name]
 
 	if !keyOK {
 		if !item.IsOptional {
-			return fmt.Errorf("map entry '%s' is not exists", name)
+			report.Add(newValidationError(item, jsonPointer(name), CodeMissing, nil, nil, "is not exists"))
 		}
 
-		return nil
+		return
 	} else if rawValue == nil && item.IsOptional {
-		return nil
+		return
 	}
 
 	if item.IsArray {
 		strArr, arrOK := rawValue.([]string)
 		if arrOK {
-			if item.EnableLenLimit {
-				for index, tmp := range strArr {
-					if len(tmp) != item.LenLimit {
-						return fmt.Errorf("%s[%d] length is not %d: %s",
-							name, index, item.LenLimit, tmp)
-					}
+			for index, tmp := range strArr {
+				if report.isDone() {
+					return
 				}
+
+				item.validateValue(tmp, jsonPointer(name, index), report)
 			}
 
-			return nil
+			return
 		}
 
 		arrStr, arrStrOK := rawValue.([]interface{})
 		if arrStrOK {
 			for index, tmp := range arrStr {
+				if report.isDone() {
+					return
+				}
+
 				tmpStr, OK := tmp.(string)
 				if !OK {
-					return fmt.Errorf("%s[%d] is not string but %s", name, index, reflect.TypeOf(tmp))
+					report.Add(newValidationError(item, jsonPointer(name, index), CodeWrongType, "string", tmp,
+						"is not string but %s", reflect.TypeOf(tmp)))
+					continue
 				}
 
-				if item.EnableLenLimit && len(tmpStr) != item.LenLimit {
-					return fmt.Errorf("%s[%d] length is not %d:%s", name, index, item.LenLimit, tmpStr)
-				}
+				item.validateValue(tmpStr, jsonPointer(name, index), report)
 			}
 
-			return nil
+			return
 		}
 
-		return fmt.Errorf("%s is not array string but %s", name, reflect.TypeOf(rawValue))
+		report.Add(newValidationError(item, jsonPointer(name), CodeWrongType, "array string", rawValue,
+			"is not array string but %s", reflect.TypeOf(rawValue)))
+		return
 	}
 
 	str, intOK := rawValue.(string)
 	if !intOK {
-		return fmt.Errorf("%s is not string but %s", name, reflect.TypeOf(rawValue))
+		report.Add(newValidationError(item, jsonPointer(name), CodeWrongType, "string", rawValue,
+			"is not string but %s", reflect.TypeOf(rawValue)))
+		return
 	}
 
+	item.validateValue(str, jsonPointer(name), report)
+}
+
+func (item DxStr) validateValue(value string, path string, report *ValidationReport) {
+	length := item.length(value)
+
+	if item.EnableLenLimit && length != item.LenLimit {
+		report.Add(newValidationError(item, path, CodeLength, item.LenLimit, value,
+			"length is not %d: %s", item.LenLimit, value))
+	}
+
+	if item.EnableMinLen && length < item.MinLen {
+		report.Add(newValidationError(item, path, CodeLength, item.MinLen, value,
+			"length is shorter than %d: %s", item.MinLen, value))
+	}
+
+	if item.EnableMaxLen && length > item.MaxLen {
+		report.Add(newValidationError(item, path, CodeLength, item.MaxLen, value,
+			"length is longer than %d: %s", item.MaxLen, value))
+	}
+
+	if item.Pattern != nil && !item.Pattern.MatchString(value) {
+		report.Add(newValidationError(item, path, CodePattern, item.Pattern.String(), value,
+			"does not match pattern %s: %s", item.Pattern.String(), value))
+	}
+}
+
+//length measure string length based on LenUnit
+func (item DxStr) length(value string) int {
+	switch item.LenUnit {
+	case LenUnitRune:
+		return utf8.RuneCountInString(value)
+	case LenUnitGrapheme:
+		return uniseg.GraphemeClusterCount(value)
+	default:
+		return len(value)
+	}
+}
+
+//lengthRange combine LenLimit, MinLen and MaxLen into single inclusive range
+func (item DxStr) lengthRange() (min int, hasMin bool, max int, hasMax bool) {
 	if item.EnableLenLimit {
-		if len(str) != item.LenLimit {
-			return fmt.Errorf("%s length is not %d: %s",
-				name, item.LenLimit, str)
-		}
+		min, hasMin, max, hasMax = item.LenLimit, true, item.LenLimit, true
+	}
+
+	if item.EnableMinLen && (!hasMin || item.MinLen > min) {
+		min, hasMin = item.MinLen, true
+	}
+
+	if item.EnableMaxLen && (!hasMax || item.MaxLen < max) {
+		max, hasMax = item.MaxLen, true
 	}
 
-	return nil
+	return min, hasMin, max, hasMax
+}
+
+//isLenUnitValid check string is a supported length unit
+func isLenUnitValid(unit string) bool {
+	switch LenUnit(unit) {
+	case LenUnitByte, LenUnitRune, LenUnitGrapheme:
+		return true
+	default:
+		return false
+	}
+}
+
+func (item DxStr) arraySpec() arraySpec {
+	return arraySpec{minItems: item.MinItems, maxItems: item.MaxItems, uniqueItems: item.UniqueItems}
 }
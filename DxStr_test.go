@@ -1,6 +1,9 @@
 package gxschema
 
-import "testing"
+import (
+	"regexp"
+	"testing"
+)
 
 func TestDxStr_ValidateData(t *testing.T) {
 	type args struct {
@@ -34,6 +37,61 @@ func TestDxStr_ValidateData(t *testing.T) {
 			item:    DxStr{Name: "customer", IsOptional: false, IsArray: true, EnableLenLimit: true, LenLimit: 6},
 			args:    args{name: "nono", input: map[string]interface{}{"nono": []string{"qwerty", "123456", "zxcghj"}}},
 			wantErr: false},
+		{
+			name:    "string byte length test",
+			item:    DxStr{Name: "customer", EnableLenLimit: true, LenLimit: 6},
+			args:    args{name: "nono", input: map[string]interface{}{"nono": "Müller"}},
+			wantErr: true},
+		{
+			name:    "string rune length test",
+			item:    DxStr{Name: "customer", EnableLenLimit: true, LenLimit: 6, LenUnit: LenUnitRune},
+			args:    args{name: "nono", input: map[string]interface{}{"nono": "Müller"}},
+			wantErr: false},
+		{
+			name:    "string grapheme length test",
+			item:    DxStr{Name: "customer", EnableMaxLen: true, MaxLen: 6, LenUnit: LenUnitGrapheme},
+			args:    args{name: "nono", input: map[string]interface{}{"nono": "Mu\u0308ller"}},
+			wantErr: false},
+		{
+			name:    "string rune length exceed test",
+			item:    DxStr{Name: "customer", EnableMaxLen: true, MaxLen: 6, LenUnit: LenUnitRune},
+			args:    args{name: "nono", input: map[string]interface{}{"nono": "Mu\u0308ller"}},
+			wantErr: true},
+		{
+			name:    "string min length test",
+			item:    DxStr{Name: "customer", EnableMinLen: true, MinLen: 2, EnableMaxLen: true, MaxLen: 4},
+			args:    args{name: "nono", input: map[string]interface{}{"nono": "q"}},
+			wantErr: true},
+		{
+			name:    "string array max length test",
+			item:    DxStr{Name: "customer", IsArray: true, EnableMinLen: true, MinLen: 2, EnableMaxLen: true, MaxLen: 4},
+			args:    args{name: "nono", input: map[string]interface{}{"nono": []string{"qw", "qwer", "qwert"}}},
+			wantErr: true},
+		{
+			name:    "string pattern test",
+			item:    DxStr{Name: "customer", Pattern: regexp.MustCompile(`^ODR\d{4}$`)},
+			args:    args{name: "nono", input: map[string]interface{}{"nono": "ODR0001"}},
+			wantErr: false},
+		{
+			name:    "string pattern mismatch test",
+			item:    DxStr{Name: "customer", Pattern: regexp.MustCompile(`^ODR\d{4}$`)},
+			args:    args{name: "nono", input: map[string]interface{}{"nono": "ODR01"}},
+			wantErr: true},
+		{
+			name:    "string array pattern mismatch test",
+			item:    DxStr{Name: "customer", IsArray: true, Pattern: regexp.MustCompile(`^ODR\d{4}$`)},
+			args:    args{name: "nono", input: map[string]interface{}{"nono": []interface{}{"ODR0001", "odr0002"}}},
+			wantErr: true},
+		{
+			name:    "string array unique test",
+			item:    DxStr{Name: "customer", IsArray: true, UniqueItems: true},
+			args:    args{name: "nono", input: map[string]interface{}{"nono": []string{"qwe", "asd", "qwe"}}},
+			wantErr: true},
+		{
+			name:    "string array max items test",
+			item:    DxStr{Name: "customer", IsArray: true, MaxItems: 2},
+			args:    args{name: "nono", input: map[string]interface{}{"nono": []string{"qwe", "asd", "zxc"}}},
+			wantErr: true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,75 @@
+package gxschema
+
+import "time"
+
+//DxTime time of day data type
+//accepted value:
+//		1. ISO 8601 time string, e.g. 15:04:05 or 15:04:05+08:00
+//		2. time.Time, only time part is considered
+type DxTime struct {
+	Name            string
+	IsOptional      bool
+	IsArray         bool
+	MinItems        int       //MinItems least number of array elements, 0 means no limit
+	MaxItems        int       //MaxItems most number of array elements, 0 means no limit
+	UniqueItems     bool      //UniqueItems array elements must be distinct
+	Format          string    //Format custom GO time layout, default is ISO 8601
+	Min             time.Time //Min earliest accepted time of day, zero value means no limit
+	Max             time.Time //Max latest accepted time of day, zero value means no limit
+	RequireTimezone bool      //RequireTimezone value must declare its UTC offset
+}
+
+//GetName get name
+func (item DxTime) GetName() string { return item.Name }
+
+//IsValueOptional is field value optional
+func (item DxTime) IsValueOptional() bool { return item.IsOptional }
+
+//IsValueArray is field value allow to store multiple values
+func (item DxTime) IsValueArray() bool { return item.IsArray }
+
+//XML generate XML
+func (item DxTime) XML(indentLevel int) string {
+	var result string
+	for i := 0; i < indentLevel; i++ {
+		result += "\t"
+	}
+	result += "<dxtime name=\"" + item.Name + "\""
+
+	if item.IsArray {
+		result += " isArray=\"true\""
+	}
+
+	if item.IsOptional {
+		result += " isOptional=\"true\""
+	}
+
+	result += item.arraySpec().xmlAttributes()
+
+	return result + item.temporal().xmlAttributes() + "></dxtime>"
+}
+
+//ValidateData validate input data
+func (item DxTime) ValidateData(input map[string]interface{}, name string) error {
+	return validateFirst(item, input, name)
+}
+
+//CollectErrors validate input data and add every violation into report
+func (item DxTime) CollectErrors(input map[string]interface{}, name string, report *ValidationReport) {
+	item.arraySpec().collectErrors(item, input, name, report)
+
+	if report.isDone() {
+		return
+	}
+
+	item.temporal().collectErrors(item, input, name, report)
+}
+
+func (item DxTime) temporal() temporalSpec {
+	return temporalSpec{kind: temporalTime, format: item.Format,
+		min: item.Min, max: item.Max, requireTimezone: item.RequireTimezone}
+}
+
+func (item DxTime) arraySpec() arraySpec {
+	return arraySpec{minItems: item.MinItems, maxItems: item.MaxItems, uniqueItems: item.UniqueItems}
+}
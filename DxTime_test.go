@@ -0,0 +1,72 @@
+package gxschema
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDxTime_ValidateData(t *testing.T) {
+	type args struct {
+		input map[string]interface{}
+		name  string
+	}
+	tests := []struct {
+		name    string
+		item    DxTime
+		args    args
+		wantErr bool
+	}{
+		// Add test cases.
+		{
+			name:    "simple time test",
+			item:    DxTime{Name: "start"},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "08:30:00"}},
+			wantErr: false,
+		},
+		{
+			name:    "time with fraction and offset test",
+			item:    DxTime{Name: "start"},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "08:30:00.125+08:00"}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid time test",
+			item:    DxTime{Name: "start"},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "25:00:00"}},
+			wantErr: true,
+		},
+		{
+			name:    "timezone required test",
+			item:    DxTime{Name: "start", RequireTimezone: true},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "08:30:00Z"}},
+			wantErr: false,
+		},
+		{
+			name:    "timezone missing test",
+			item:    DxTime{Name: "start", RequireTimezone: true},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "08:30:00"}},
+			wantErr: true,
+		},
+		{
+			name: "office hour test",
+			item: DxTime{Name: "start",
+				Min: time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC),
+				Max: time.Date(0, 1, 1, 18, 0, 0, 0, time.UTC)},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": "18:00:01"}},
+			wantErr: true,
+		},
+		{
+			name:    "time array test",
+			item:    DxTime{Name: "slots", IsArray: true},
+			args:    args{name: "koko", input: map[string]interface{}{"koko": []interface{}{"08:00", "12:30"}}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.item.ValidateData(tt.args.input, tt.args.name); (err != nil) != tt.wantErr {
+				t.Errorf("DxTime.ValidateData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package gxschema
+
+//DxType named group of DxItem(s) declared in DxDoc, reused by DxSection which refers to it by name
+type DxType struct {
+	Name  string
+	Items []DxItem
+}
+
+//XML generate XML
+func (dxtype DxType) XML(indentLevel int) string {
+	var indent string
+	for i := 0; i < indentLevel; i++ {
+		indent += "\t"
+	}
+	result := indent + "<dxtype name=\"" + dxtype.Name + "\">"
+
+	for _, item := range dxtype.Items {
+		result += "\n" + item.XML(indentLevel+1)
+	}
+
+	return result + "\n" + indent + "</dxtype>"
+}
@@ -0,0 +1,89 @@
+package gxschema
+
+import (
+	"fmt"
+	"sync"
+)
+
+//ItemParser parse XML node of custom item type into DxItem,
+//returned item's XML() shall generate the same tag so schema can round trip
+type ItemParser func(node *XMLNode) (DxItem, error)
+
+//XSDItem custom item which can be exported by DxDoc.XSD
+type XSDItem interface {
+	DxItem
+	XSDType() (base string, facets []string) //XSDType base type (e.g. xs:string) and restriction facets (e.g. <xs:length value="34"/>)
+}
+
+//JSONSchemaItem custom item which can be exported by DxDoc.JSONSchema
+type JSONSchemaItem interface {
+	DxItem
+	JSONSchema() map[string]interface{} //JSONSchema schema of single value, array wrapper is added by exporter
+}
+
+var builtInItemTags = []string{"dxbool", "dxint", "dxdecimal", "dxstr", "dxenum",
+	"dxdate", "dxtime", "dxdatetime", "dxfile", "dxsection", "dxtype", "dxinclude", "dxremove"}
+
+var itemRegistry = struct {
+	sync.RWMutex
+	parsers map[string]ItemParser
+}{parsers: make(map[string]ItemParser)}
+
+//RegisterItemType register custom item tag so ParseSchemaFromXML accepts it at any nesting level;
+//built-in tag and tag which is already registered can't be registered again
+func RegisterItemType(tagName string, parser ItemParser) error {
+	if tagName == "" || parser == nil {
+		return fmt.Errorf("item type requires tag name and parser")
+	}
+
+	if tagName == "dxdoc" || isStringInSlice(tagName, builtInItemTags) {
+		return fmt.Errorf("tag <%s> is built-in item type", tagName)
+	}
+
+	itemRegistry.Lock()
+	defer itemRegistry.Unlock()
+
+	if _, ok := itemRegistry.parsers[tagName]; ok {
+		return fmt.Errorf("tag <%s> is already registered", tagName)
+	}
+
+	itemRegistry.parsers[tagName] = parser
+
+	return nil
+}
+
+//UnregisterItemType remove custom item tag from registry
+func UnregisterItemType(tagName string) {
+	itemRegistry.Lock()
+	defer itemRegistry.Unlock()
+
+	delete(itemRegistry.parsers, tagName)
+}
+
+//findItemParser get parser of registered custom item tag
+func findItemParser(tagName string) (ItemParser, bool) {
+	itemRegistry.RLock()
+	defer itemRegistry.RUnlock()
+
+	parser, ok := itemRegistry.parsers[tagName]
+
+	return parser, ok
+}
+
+//walkCustomItem parse custom item node with its registered parser
+func walkCustomItem(node *XMLNode, parser ItemParser) (DxItem, error) {
+	item, err := parser(node)
+	if err != nil {
+		return nil, err
+	}
+
+	if item == nil {
+		return nil, fmt.Errorf("parser of <%s> returns no item", node.XMLName.Local)
+	}
+
+	if err := validatePropertyName(item.GetName()); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
@@ -0,0 +1,162 @@
+package gxschema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var ibanPattern = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$`)
+
+//dxIBAN custom item type used to test item registry
+type dxIBAN struct {
+	Name       string
+	IsOptional bool
+}
+
+func (item dxIBAN) GetName() string       { return item.Name }
+func (item dxIBAN) IsValueOptional() bool { return item.IsOptional }
+func (item dxIBAN) IsValueArray() bool    { return false }
+
+func (item dxIBAN) XML(indentLevel int) string {
+	result := strings.Repeat("\t", indentLevel) + "<dxiban name=\"" + item.Name + "\""
+
+	if item.IsOptional {
+		result += " isOptional=\"true\""
+	}
+
+	return result + "></dxiban>"
+}
+
+//ValidateData dxIBAN implements ValidateData only, so ValidateAll falls back to the error it returns
+func (item dxIBAN) ValidateData(input map[string]interface{}, name string) error {
+	rawValue, ok := input[name]
+	if !ok {
+		if !item.IsOptional {
+			return fmt.Errorf("%s is not exists", name)
+		}
+
+		return nil
+	}
+
+	if value, strOK := rawValue.(string); !strOK || !ibanPattern.MatchString(value) {
+		return fmt.Errorf("%s is not IBAN: %v", name, rawValue)
+	}
+
+	return nil
+}
+
+func (item dxIBAN) XSDType() (string, []string) {
+	return "xs:string", []string{`<xs:pattern value="[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}"/>`}
+}
+
+func (item dxIBAN) JSONSchema() map[string]interface{} {
+	return map[string]interface{}{"type": "string", "pattern": ibanPattern.String()}
+}
+
+func parseDxIBAN(node *XMLNode) (DxItem, error) {
+	item := dxIBAN{}
+
+	for _, attribute := range node.Attributes {
+		switch attribute.Name.Local {
+		case "name":
+			item.Name = attribute.Value
+		case "isOptional":
+			item.IsOptional = attribute.Value == "true"
+		default:
+			return nil, fmt.Errorf("unknown attribute %s", attribute.Name.Local)
+		}
+	}
+
+	return item, nil
+}
+
+func TestRegisterItemType(t *testing.T) {
+	if err := RegisterItemType("dxiban", parseDxIBAN); err != nil {
+		t.Error(err)
+		return
+	}
+	defer UnregisterItemType("dxiban")
+
+	rawXML := `<?xml version="1.0"?>
+<dxdoc name="supplier" revision="1" id="7a9c1e3b-6d8f-4a0b-9c2d-4e6f8a0b2c4d">
+	<dxiban name="iban"></dxiban>
+	<dxsection name="bank" isOptional="true">
+		<dxstr name="name"></dxstr>
+		<dxiban name="iban" isOptional="true"></dxiban>
+	</dxsection>
+</dxdoc>`
+
+	dx, dxErr := ParseSchemaFromXML(rawXML)
+	if dxErr != nil {
+		t.Error(dxErr)
+		return
+	}
+
+	xmlStr, xmlErr := dx.XML()
+	if xmlErr != nil {
+		t.Error(xmlErr)
+		return
+	}
+
+	if strings.Compare(xmlStr, rawXML) != 0 {
+		t.Errorf("XML output not tally with [output]: \n%s\n\n[expected]:\n%s", xmlStr, rawXML)
+	}
+
+	if err := dx.ValidateData(map[string]interface{}{"iban": "DE89370400440532013000"}); err != nil {
+		t.Error(err)
+	}
+
+	report := dx.ValidateAll(map[string]interface{}{
+		"iban": "DE89370400440532013000",
+		"bank": map[string]interface{}{"name": "abc", "iban": "12345"},
+	})
+	if len(report.Errors) != 1 || report.Errors[0].Path != "/bank/iban" || report.Errors[0].Code != CodeInvalid {
+		t.Errorf("expect single invalid violation at /bank/iban but get:\n%s", report.Error())
+	}
+
+	xsdStr, xsdErr := dx.XSD()
+	if xsdErr != nil {
+		t.Error(xsdErr)
+	} else if !strings.Contains(xsdStr, `<xs:pattern value="[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}"/>`) {
+		t.Errorf("expect iban declared with pattern:\n%s", xsdStr)
+	}
+
+	jsonStr, jsonErr := dx.JSONSchema()
+	if jsonErr != nil {
+		t.Error(jsonErr)
+	} else if !strings.Contains(jsonStr, `"pattern": "^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$"`) {
+		t.Errorf("expect iban declared with pattern:\n%s", jsonStr)
+	}
+}
+
+func TestRegisterItemType_expectFail(t *testing.T) {
+	if err := RegisterItemType("dxstr", parseDxIBAN); err == nil {
+		t.Error("expect built-in tag can't be registered")
+	}
+
+	if err := RegisterItemType("dxsku", nil); err == nil {
+		t.Error("expect tag without parser can't be registered")
+	}
+
+	if err := RegisterItemType("dxiban", parseDxIBAN); err != nil {
+		t.Error(err)
+		return
+	}
+	defer UnregisterItemType("dxiban")
+
+	if err := RegisterItemType("dxiban", parseDxIBAN); err == nil {
+		t.Error("expect tag can't be registered twice")
+	}
+
+	rawXML := `<dxdoc name="supplier" revision="1" id="1"><dxiban name="iban" isArray="true"></dxiban></dxdoc>`
+	if _, err := ParseSchemaFromXML(rawXML); err == nil {
+		t.Error("expect error returned by registered parser")
+	}
+
+	rawXML = `<dxdoc name="supplier" revision="1" id="1"><dxiban name="filename"></dxiban></dxdoc>`
+	if _, err := ParseSchemaFromXML(rawXML); err == nil {
+		t.Error("expect reserved name is rejected for custom item")
+	}
+}
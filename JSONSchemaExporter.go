@@ -0,0 +1,263 @@
+package gxschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+//JSONSchemaDraft JSON Schema dialect generated by DxDoc.JSONSchema
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+//JSONSchemaID generate JSON Schema $id of a document, format: urn:dxdoc:<ID>:<Revision>
+func JSONSchemaID(doc DxDoc) string {
+	return fmt.Sprintf("urn:dxdoc:%s:%d", doc.ID, doc.Revision)
+}
+
+//JSONSchema generate document definition into JSON Schema (draft 2020-12) format
+func (doc DxDoc) JSONSchema() (string, error) {
+	properties, required, err := jsonSchemaProperties(doc.Items)
+	if err != nil {
+		return "", err
+	}
+
+	schema := jsonObject{
+		{"$schema", JSONSchemaDraft},
+		{"$id", JSONSchemaID(doc)},
+		{"title", doc.Name},
+		{"type", "object"},
+		{"properties", properties},
+	}
+
+	if len(required) > 0 {
+		schema = append(schema, jsonMember{"required", required})
+	}
+
+	if doc.IsStrict {
+		schema = append(schema, jsonMember{"additionalProperties", false})
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "\t")
+
+	if err := encoder.Encode(schema); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+//jsonSchemaProperties generate 'properties' and 'required' keyword value of items
+func jsonSchemaProperties(items []DxItem) (jsonObject, []string, error) {
+	properties := jsonObject{}
+	required := []string{}
+
+	for _, item := range items {
+		schema, err := jsonSchemaItem(item)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		properties = append(properties, jsonMember{item.GetName(), schema})
+
+		if !item.IsValueOptional() {
+			required = append(required, item.GetName())
+		}
+	}
+
+	return properties, required, nil
+}
+
+//jsonSchemaItem generate JSON Schema of a document item
+func jsonSchemaItem(item DxItem) (jsonObject, error) {
+	var schema jsonObject
+
+	switch tmp := dereferenceItem(item).(type) {
+	case DxStr:
+		schema = jsonObject{{"type", "string"}}
+
+		//JSON Schema measures length in unicode code points regardless of LenUnit
+		min, hasMin, max, hasMax := tmp.lengthRange()
+		if hasMin {
+			schema = append(schema, jsonMember{"minLength", min})
+		}
+
+		if hasMax {
+			schema = append(schema, jsonMember{"maxLength", max})
+		}
+
+		if tmp.Pattern != nil {
+			schema = append(schema, jsonMember{"pattern", tmp.Pattern.String()})
+		}
+	case DxInt:
+		schema = append(jsonObject{{"type", "integer"}}, jsonSchemaRange(tmp.numericRange())...)
+	case DxDecimal:
+		schema = append(jsonObject{
+			{"type", "number"},
+			{"multipleOf", json.Number(decimal.New(1, int32(-tmp.Precision)).String())},
+		}, jsonSchemaRange(tmp.numericRange())...)
+	case DxBool:
+		schema = jsonObject{{"type", "boolean"}}
+	case DxDate:
+		schema = jsonSchemaTemporal(tmp.temporal())
+	case DxTime:
+		schema = jsonSchemaTemporal(tmp.temporal())
+	case DxDateTime:
+		schema = jsonSchemaTemporal(tmp.temporal())
+	case DxEnum:
+		schema = jsonObject{{"type", "string"}}
+
+		//case insensitive option has no JSON Schema equivalent, hence declared as plain string
+		if !tmp.IgnoreCase {
+			schema = append(schema, jsonMember{"enum", tmp.optionValues()})
+		}
+	case DxFile:
+		schema = jsonObject{
+			{"type", "object"},
+			{"properties", jsonObject{
+				{"filename", jsonObject{{"type", "string"}}},
+				{"filepath", jsonObject{{"type", "string"}}},
+			}},
+			{"required", []string{"filename", "filepath"}},
+		}
+	case DxSection:
+		properties, required, err := jsonSchemaProperties(tmp.Items)
+		if err != nil {
+			return nil, err
+		}
+
+		schema = jsonObject{{"type", "object"}, {"properties", properties}}
+
+		if len(required) > 0 {
+			schema = append(schema, jsonMember{"required", required})
+		}
+
+		if tmp.IsStrict {
+			schema = append(schema, jsonMember{"additionalProperties", false})
+		}
+	case JSONSchemaItem:
+		custom := tmp.JSONSchema()
+
+		//map has no member order, hence members are sorted by key
+		keys := make([]string, 0, len(custom))
+		for key := range custom {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			schema = append(schema, jsonMember{key, custom[key]})
+		}
+	default:
+		return nil, fmt.Errorf("'%s' has no JSON Schema equivalent, unsupported item type %s",
+			item.GetName(), reflect.TypeOf(item))
+	}
+
+	if item.IsValueArray() {
+		array := jsonObject{{"type", "array"}, {"items", schema}}
+
+		//uniqueBy has no JSON Schema equivalent, hence not exported
+		spec := itemArraySpec(item)
+		if spec.minItems > 0 {
+			array = append(array, jsonMember{"minItems", spec.minItems})
+		}
+
+		if spec.maxItems > 0 {
+			array = append(array, jsonMember{"maxItems", spec.maxItems})
+		}
+
+		if spec.uniqueItems {
+			array = append(array, jsonMember{"uniqueItems", true})
+		}
+
+		return array, nil
+	}
+
+	return schema, nil
+}
+
+//jsonSchemaRange generate minimum and maximum keywords of dxint and dxdecimal
+func jsonSchemaRange(spec numericRange) jsonObject {
+	var result jsonObject
+
+	if spec.enableMin {
+		keyword := "minimum"
+		if spec.exclusiveMin {
+			keyword = "exclusiveMinimum"
+		}
+
+		result = append(result, jsonMember{keyword, json.Number(spec.min.String())})
+	}
+
+	if spec.enableMax {
+		keyword := "maximum"
+		if spec.exclusiveMax {
+			keyword = "exclusiveMaximum"
+		}
+
+		result = append(result, jsonMember{keyword, json.Number(spec.max.String())})
+	}
+
+	return result
+}
+
+//jsonSchemaTemporal generate JSON Schema of dxdate, dxtime or dxdatetime;
+//custom format has no JSON Schema equivalent, hence declared as plain string.
+//RFC 3339 'time' and 'date-time' formats require second and UTC offset, so accepted layouts of dxtime and
+//dxdatetime are declared by pattern instead
+func jsonSchemaTemporal(spec temporalSpec) jsonObject {
+	if spec.format != "" {
+		return jsonObject{{"type", "string"}}
+	}
+
+	if spec.kind != temporalDate {
+		return jsonObject{{"type", "string"}, {"pattern", "^" + spec.pattern() + "$"}}
+	}
+
+	return jsonObject{{"type", "string"}, {"format", "date"}}
+}
+
+//jsonObject JSON object which keeps its members order
+type jsonObject []jsonMember
+
+//jsonMember single member of JSON object
+type jsonMember struct {
+	Key   string
+	Value interface{}
+}
+
+//MarshalJSON encode members into JSON object by its order
+func (object jsonObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+
+	buffer.WriteString("{")
+
+	for index, member := range object {
+		if index > 0 {
+			buffer.WriteString(",")
+		}
+
+		if err := encoder.Encode(member.Key); err != nil {
+			return nil, err
+		}
+
+		buffer.WriteString(":")
+
+		if err := encoder.Encode(member.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	buffer.WriteString("}")
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,197 @@
+package gxschema
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestDxDoc_JSONSchema(t *testing.T) {
+	doc := DxDoc{
+		Name:     "invoice",
+		Revision: 3,
+		ID:       "733bee1b-f79a-4cb7-b675-842317b994b5",
+		Items: []DxItem{
+			DxStr{Name: "docNo", EnableLenLimit: true, LenLimit: 6},
+			&DxDecimal{Name: "total", Precision: 2},
+			DxBool{Name: "isPaid", IsOptional: true},
+			DxDateTime{Name: "createdAt"},
+			DxFile{Name: "attachment", IsOptional: true},
+			DxSection{Name: "items", IsArray: true, Items: []DxItem{
+				DxStr{Name: "description"},
+				DxInt{Name: "qty", IsArray: true},
+			}},
+		},
+	}
+
+	jsonStr, jsonErr := doc.JSONSchema()
+	if jsonErr != nil {
+		t.Error(jsonErr)
+		return
+	}
+
+	expectedJSON := `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"$id": "urn:dxdoc:733bee1b-f79a-4cb7-b675-842317b994b5:3",
+	"title": "invoice",
+	"type": "object",
+	"properties": {
+		"docNo": {
+			"type": "string",
+			"minLength": 6,
+			"maxLength": 6
+		},
+		"total": {
+			"type": "number",
+			"multipleOf": 0.01
+		},
+		"isPaid": {
+			"type": "boolean"
+		},
+		"createdAt": {
+			"type": "string",
+			"pattern": "^\\d{4}-\\d{2}-\\d{2}T\\d{1,2}:\\d{2}(:\\d{2}([.,]\\d+)?)?(Z|[+\\-]\\d{2}:\\d{2})?$"
+		},
+		"attachment": {
+			"type": "object",
+			"properties": {
+				"filename": {
+					"type": "string"
+				},
+				"filepath": {
+					"type": "string"
+				}
+			},
+			"required": [
+				"filename",
+				"filepath"
+			]
+		},
+		"items": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"properties": {
+					"description": {
+						"type": "string"
+					},
+					"qty": {
+						"type": "array",
+						"items": {
+							"type": "integer"
+						}
+					}
+				},
+				"required": [
+					"description",
+					"qty"
+				]
+			}
+		}
+	},
+	"required": [
+		"docNo",
+		"total",
+		"createdAt",
+		"items"
+	]
+}`
+
+	if strings.Compare(jsonStr, expectedJSON) != 0 {
+		t.Errorf("JSON Schema output not tally with [output]: \n%s\n\n[expected]:\n%s", jsonStr, expectedJSON)
+	}
+
+	if !json.Valid([]byte(jsonStr)) {
+		t.Error("JSON Schema output is not valid JSON")
+	}
+}
+
+func TestDxDoc_JSONSchema_temporal(t *testing.T) {
+	doc := DxDoc{Name: "shift", Revision: 1, ID: "1", Items: []DxItem{
+		DxDate{Name: "workDate"},
+		DxTime{Name: "startTime"},
+		DxDateTime{Name: "clockIn"},
+		DxDateTime{Name: "clockOut", RequireTimezone: true},
+	}}
+
+	jsonStr, jsonErr := doc.JSONSchema()
+	if jsonErr != nil {
+		t.Error(jsonErr)
+		return
+	}
+
+	var schema struct {
+		Properties map[string]struct {
+			Format  string
+			Pattern string
+		}
+	}
+
+	if err := json.Unmarshal([]byte(jsonStr), &schema); err != nil {
+		t.Error(err)
+		return
+	}
+
+	formats := map[string]string{"workDate": "date", "startTime": "", "clockIn": "", "clockOut": ""}
+	for name, format := range formats {
+		if schema.Properties[name].Format != format {
+			t.Errorf("expect %s has format '%s' but get '%s'", name, format, schema.Properties[name].Format)
+		}
+	}
+
+	//every value accepted by item must match pattern
+	testCases := []struct {
+		name   string
+		values []string
+	}{
+		{"startTime", []string{"15:04", "15:04:05", "15:04:05.123", "15:04Z", "15:04:05+08:00"}},
+		{"clockIn", []string{"2006-01-02T15:04", "2006-01-02T15:04:05", "2006-01-02T15:04:05.5Z", "2006-01-02T15:04-07:00"}},
+		{"clockOut", []string{"2006-01-02T15:04+08:00", "2006-01-02T15:04:05Z", "2006-01-02T15:04:05.5-07:00"}},
+	}
+
+	for _, testCase := range testCases {
+		pattern, err := regexp.Compile(schema.Properties[testCase.name].Pattern)
+		if err != nil {
+			t.Errorf("invalid pattern of %s: %s", testCase.name, err.Error())
+			continue
+		}
+
+		for _, value := range testCase.values {
+			data := map[string]interface{}{"workDate": "2006-01-02", "startTime": "15:04", "clockIn": "2006-01-02T15:04",
+				"clockOut": "2006-01-02T15:04Z", testCase.name: value}
+			if err := doc.ValidateData(data); err != nil {
+				t.Errorf("expect %s accepts %s but get: %s", testCase.name, value, err.Error())
+			}
+
+			if !pattern.MatchString(value) {
+				t.Errorf("expect pattern of %s matches %s: %s", testCase.name, value, pattern.String())
+			}
+		}
+
+		if pattern.MatchString("2006-01-02") {
+			t.Errorf("expect pattern of %s rejects date only value", testCase.name)
+		}
+	}
+
+	if regexp.MustCompile(schema.Properties["clockOut"].Pattern).MatchString("2006-01-02T15:04") {
+		t.Error("expect pattern of clockOut rejects value without UTC offset")
+	}
+}
+
+func TestDxDoc_JSONSchema_precision(t *testing.T) {
+	doc := DxDoc{Name: "rate", Revision: 1, ID: "1", Items: []DxItem{
+		DxDecimal{Name: "whole", Precision: 0},
+		DxDecimal{Name: "fine", Precision: 4},
+	}}
+
+	jsonStr, jsonErr := doc.JSONSchema()
+	if jsonErr != nil {
+		t.Error(jsonErr)
+		return
+	}
+
+	if !strings.Contains(jsonStr, `"multipleOf": 1`) || !strings.Contains(jsonStr, `"multipleOf": 0.0001`) {
+		t.Errorf("unexpected multipleOf value:\n%s", jsonStr)
+	}
+}
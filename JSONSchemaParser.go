@@ -0,0 +1,649 @@
+package gxschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+var jsonSchemaIDPattern = regexp.MustCompile(`^urn:dxdoc:(.+):(\d+)$`)
+
+//annotation keywords do not affect validation, hence safe to ignore
+var jsonSchemaAnnotations = []string{"title", "description", "$comment", "examples",
+	"default", "deprecated", "readOnly", "writeOnly"}
+
+//UnsupportedKeywordError JSON Schema keywords which have no DxDoc equivalent
+type UnsupportedKeywordError struct {
+	Keywords []string //Keywords location of each keyword in JSON Pointer format, e.g. #/properties/qty/minimum
+}
+
+func (err *UnsupportedKeywordError) Error() string {
+	return fmt.Sprintf("unable to represent JSON Schema keyword(s): %s", strings.Join(err.Keywords, ", "))
+}
+
+//ParseSchemaFromJSONSchema parse document schema (DxDoc) from JSON Schema string;
+//document name is taken from 'title', ID and revision are taken from '$id' (urn:dxdoc:<ID>:<Revision>),
+//other '$id' value is used as ID with revision 1.
+//*UnsupportedKeywordError is returned when any keyword can't be represented by DxDoc
+func ParseSchemaFromJSONSchema(rawJSON string) (*DxDoc, error) {
+	decoder := json.NewDecoder(strings.NewReader(rawJSON))
+	decoder.UseNumber()
+
+	rawValue, err := decodeJSONValue(decoder)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse JSON string: %s", err.Error())
+	}
+
+	root, rootOK := rawValue.(jsonObject)
+	if !rootOK {
+		return nil, fmt.Errorf("JSON Schema root is not an object")
+	}
+
+	walker := &jsonSchemaWalker{}
+	used := map[string]bool{"$schema": true, "$id": true}
+
+	doc := &DxDoc{Revision: 1}
+
+	if rawID, ok := root.get("$id"); ok {
+		id, idOK := rawID.(string)
+		if !idOK {
+			return nil, fmt.Errorf("'$id' is not string")
+		}
+
+		if matches := jsonSchemaIDPattern.FindStringSubmatch(id); matches != nil {
+			doc.ID = matches[1]
+			doc.Revision, _ = strconv.Atoi(matches[2])
+		} else {
+			doc.ID = id
+		}
+	}
+
+	rawTitle, titleOK := root.get("title")
+	if !titleOK {
+		return nil, fmt.Errorf("missing keyword 'title' as document name")
+	}
+
+	title, titleStrOK := rawTitle.(string)
+	if !titleStrOK {
+		return nil, fmt.Errorf("'title' is not string")
+	}
+
+	if err := validatePropertyName(title); err != nil {
+		return nil, err
+	}
+
+	doc.Name = title
+
+	if schemaType, _ := root.get("type"); schemaType != "object" {
+		return nil, fmt.Errorf("JSON Schema root type must be 'object'")
+	}
+
+	items, strict, err := walker.walkProperties(root, "#", used)
+	if err != nil {
+		return nil, err
+	}
+
+	walker.reportUnused(root, "#", used)
+
+	if len(walker.unsupported) > 0 {
+		sort.Strings(walker.unsupported)
+		return nil, &UnsupportedKeywordError{Keywords: walker.unsupported}
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("DxDoc must atleast declare one data type definition")
+	}
+
+	doc.Items = items
+	doc.IsStrict = strict
+
+	return doc, nil
+}
+
+//jsonSchemaWalker convert JSON Schema into DxItem(s) and keep track of unsupported keywords
+type jsonSchemaWalker struct {
+	unsupported []string
+}
+
+//walkProperties convert 'properties' and 'required' keywords of an object schema into items,
+//second return value tell whether 'additionalProperties' is false
+func (walker *jsonSchemaWalker) walkProperties(schema jsonObject, pointer string,
+	used map[string]bool) ([]DxItem, bool, error) {
+	used["type"] = true
+	used["properties"] = true
+	used["required"] = true
+
+	strict := false
+
+	if rawAdditional, ok := schema.get("additionalProperties"); ok {
+		//only boolean value is supported, schema value constrains undeclared keys
+		if additional, boolOK := rawAdditional.(bool); boolOK {
+			used["additionalProperties"] = true
+			strict = !additional
+		}
+	}
+
+	var requiredNames []string
+
+	if rawRequired, ok := schema.get("required"); ok {
+		arr, arrOK := rawRequired.([]interface{})
+		if !arrOK {
+			return nil, false, fmt.Errorf("%s/required is not array", pointer)
+		}
+
+		for _, rawName := range arr {
+			name, nameOK := rawName.(string)
+			if !nameOK {
+				return nil, false, fmt.Errorf("%s/required contains non string value", pointer)
+			}
+
+			requiredNames = append(requiredNames, name)
+		}
+	}
+
+	var properties jsonObject
+
+	if rawProperties, ok := schema.get("properties"); ok {
+		tmp, objOK := rawProperties.(jsonObject)
+		if !objOK {
+			return nil, false, fmt.Errorf("%s/properties is not object", pointer)
+		}
+
+		properties = tmp
+	}
+
+	var items []DxItem
+
+	for _, property := range properties {
+		propertySchema, objOK := property.Value.(jsonObject)
+		if !objOK {
+			return nil, false, fmt.Errorf("%s/properties/%s is not object", pointer, property.Key)
+		}
+
+		if err := validatePropertyName(property.Key); err != nil {
+			return nil, false, err
+		}
+
+		item, err := walker.walkItem(property.Key, propertySchema,
+			pointer+"/properties/"+property.Key, !isStringInSlice(property.Key, requiredNames))
+		if err != nil {
+			return nil, false, err
+		}
+
+		if item != nil {
+			items = append(items, item)
+		}
+	}
+
+	for _, name := range requiredNames {
+		if _, ok := properties.get(name); !ok {
+			return nil, false, fmt.Errorf("%s/required refers to undeclared property '%s'", pointer, name)
+		}
+	}
+
+	return items, strict, nil
+}
+
+//walkItem convert single property schema into item, return nil item if it can't be represented
+func (walker *jsonSchemaWalker) walkItem(name string, schema jsonObject, pointer string, optional bool) (DxItem, error) {
+	used := make(map[string]bool)
+
+	array := false
+	arrSpec := arraySpec{}
+	itemSchema := schema
+	itemPointer := pointer
+
+	if schemaType, _ := schema.get("type"); schemaType == "array" {
+		used["type"] = true
+		used["items"] = true
+
+		arrSpec.minItems, used["minItems"] = parseJSONSchemaLength(schema, "minItems")
+		arrSpec.maxItems, used["maxItems"] = parseJSONSchemaLength(schema, "maxItems")
+		arrSpec.uniqueItems, used["uniqueItems"] = schema.getBool("uniqueItems")
+
+		walker.reportUnused(schema, pointer, used)
+
+		rawItems, itemsOK := schema.get("items")
+		if !itemsOK {
+			walker.addUnsupported(pointer + "/type")
+			return nil, nil
+		}
+
+		tmp, objOK := rawItems.(jsonObject)
+		if !objOK {
+			walker.addUnsupported(pointer + "/items")
+			return nil, nil
+		}
+
+		array = true
+		itemSchema = tmp
+		itemPointer = pointer + "/items"
+		used = make(map[string]bool)
+	}
+
+	item, err := walker.walkValue(name, itemSchema, itemPointer, optional, array, used)
+	if err != nil {
+		return nil, err
+	}
+
+	walker.reportUnused(itemSchema, itemPointer, used)
+
+	if item == nil {
+		return nil, nil
+	}
+
+	return withArraySpec(item, arrSpec), nil
+}
+
+//withArraySpec copy array constraints into item
+func withArraySpec(item DxItem, spec arraySpec) DxItem {
+	switch tmp := item.(type) {
+	case DxStr:
+		tmp.MinItems, tmp.MaxItems, tmp.UniqueItems = spec.minItems, spec.maxItems, spec.uniqueItems
+		return tmp
+	case DxInt:
+		tmp.MinItems, tmp.MaxItems, tmp.UniqueItems = spec.minItems, spec.maxItems, spec.uniqueItems
+		return tmp
+	case DxDecimal:
+		tmp.MinItems, tmp.MaxItems, tmp.UniqueItems = spec.minItems, spec.maxItems, spec.uniqueItems
+		return tmp
+	case DxBool:
+		tmp.MinItems, tmp.MaxItems, tmp.UniqueItems = spec.minItems, spec.maxItems, spec.uniqueItems
+		return tmp
+	case DxEnum:
+		tmp.MinItems, tmp.MaxItems, tmp.UniqueItems = spec.minItems, spec.maxItems, spec.uniqueItems
+		return tmp
+	case DxDate:
+		tmp.MinItems, tmp.MaxItems, tmp.UniqueItems = spec.minItems, spec.maxItems, spec.uniqueItems
+		return tmp
+	case DxTime:
+		tmp.MinItems, tmp.MaxItems, tmp.UniqueItems = spec.minItems, spec.maxItems, spec.uniqueItems
+		return tmp
+	case DxDateTime:
+		tmp.MinItems, tmp.MaxItems, tmp.UniqueItems = spec.minItems, spec.maxItems, spec.uniqueItems
+		return tmp
+	case DxFile:
+		tmp.MinItems, tmp.MaxItems, tmp.UniqueItems = spec.minItems, spec.maxItems, spec.uniqueItems
+		return tmp
+	case DxSection:
+		tmp.MinItems, tmp.MaxItems, tmp.UniqueItems = spec.minItems, spec.maxItems, spec.uniqueItems
+		return tmp
+	}
+
+	return item
+}
+
+//walkValue convert value schema into item based on its 'type' keyword
+func (walker *jsonSchemaWalker) walkValue(name string, schema jsonObject, pointer string,
+	optional bool, array bool, used map[string]bool) (DxItem, error) {
+	rawType, typeOK := schema.get("type")
+	schemaType, typeStrOK := rawType.(string)
+	if !typeOK || !typeStrOK {
+		walker.addUnsupported(pointer + "/type")
+		return nil, nil
+	}
+
+	used["type"] = true
+
+	switch schemaType {
+	case "string":
+		return walker.walkString(name, schema, pointer, optional, array, used), nil
+	case "integer":
+		spec := walker.walkNumericRange(schema, pointer, used)
+
+		return DxInt{Name: name, IsOptional: optional, IsArray: array,
+			EnableMin: spec.enableMin, Min: spec.min, ExclusiveMin: spec.exclusiveMin,
+			EnableMax: spec.enableMax, Max: spec.max, ExclusiveMax: spec.exclusiveMax}, nil
+	case "number":
+		rawMultiple, multipleOK := schema.get("multipleOf")
+		if !multipleOK {
+			//number without 'multipleOf' has unlimited precision
+			walker.addUnsupported(pointer + "/type")
+			return nil, nil
+		}
+
+		used["multipleOf"] = true
+
+		precision, precisionOK := parseJSONSchemaPrecision(rawMultiple)
+		if !precisionOK {
+			walker.addUnsupported(pointer + "/multipleOf")
+			return nil, nil
+		}
+
+		spec := walker.walkNumericRange(schema, pointer, used)
+
+		return DxDecimal{Name: name, IsOptional: optional, IsArray: array, Precision: precision,
+			EnableMin: spec.enableMin, Min: spec.min, ExclusiveMin: spec.exclusiveMin,
+			EnableMax: spec.enableMax, Max: spec.max, ExclusiveMax: spec.exclusiveMax}, nil
+	case "boolean":
+		return DxBool{Name: name, IsOptional: optional, IsArray: array}, nil
+	case "object":
+		if isJSONSchemaFile(schema) {
+			used["properties"] = true
+			used["required"] = true
+
+			return DxFile{Name: name, IsOptional: optional, IsArray: array}, nil
+		}
+
+		items, strict, err := walker.walkProperties(schema, pointer, used)
+		if err != nil {
+			return nil, err
+		}
+
+		return DxSection{Name: name, IsOptional: optional, IsArray: array, Items: items, IsStrict: strict}, nil
+	}
+
+	walker.addUnsupported(pointer + "/type")
+
+	return nil, nil
+}
+
+func (walker *jsonSchemaWalker) walkString(name string, schema jsonObject, pointer string,
+	optional bool, array bool, used map[string]bool) DxItem {
+	if rawFormat, ok := schema.get("format"); ok {
+		used["format"] = true
+
+		//RFC 3339 'time' and 'date-time' formats require UTC offset
+		switch rawFormat {
+		case "date":
+			return DxDate{Name: name, IsOptional: optional, IsArray: array}
+		case "time":
+			return DxTime{Name: name, IsOptional: optional, IsArray: array, RequireTimezone: true}
+		case "date-time":
+			return DxDateTime{Name: name, IsOptional: optional, IsArray: array, RequireTimezone: true}
+		}
+
+		walker.addUnsupported(pointer + "/format")
+
+		return nil
+	}
+
+	//pattern generated by JSONSchema() for dxtime and dxdatetime
+	if rawPattern, ok := schema.get("pattern"); ok {
+		for _, requireTimezone := range []bool{false, true} {
+			switch rawPattern {
+			case "^" + (temporalSpec{kind: temporalTime, requireTimezone: requireTimezone}).pattern() + "$":
+				used["pattern"] = true
+				return DxTime{Name: name, IsOptional: optional, IsArray: array, RequireTimezone: requireTimezone}
+			case "^" + (temporalSpec{kind: temporalDateTime, requireTimezone: requireTimezone}).pattern() + "$":
+				used["pattern"] = true
+				return DxDateTime{Name: name, IsOptional: optional, IsArray: array, RequireTimezone: requireTimezone}
+			}
+		}
+	}
+
+	if rawEnum, ok := schema.get("enum"); ok {
+		used["enum"] = true
+
+		enum := DxEnum{Name: name, IsOptional: optional, IsArray: array}
+
+		values, arrOK := rawEnum.([]interface{})
+		for _, rawValue := range values {
+			value, strOK := rawValue.(string)
+			if !strOK || enum.findOption(value) >= 0 {
+				arrOK = false
+				break
+			}
+
+			enum.Options = append(enum.Options, DxOption{Value: value})
+		}
+
+		if !arrOK || len(enum.Options) == 0 {
+			walker.addUnsupported(pointer + "/enum")
+			return nil
+		}
+
+		return enum
+	}
+
+	item := DxStr{Name: name, IsOptional: optional, IsArray: array}
+
+	minLen, hasMin := parseJSONSchemaLength(schema, "minLength")
+	maxLen, hasMax := parseJSONSchemaLength(schema, "maxLength")
+
+	if hasMin && hasMax && minLen == maxLen {
+		item.EnableLenLimit = true
+		item.LenLimit = minLen
+	} else {
+		item.EnableMinLen, item.MinLen = hasMin, minLen
+		item.EnableMaxLen, item.MaxLen = hasMax, maxLen
+	}
+
+	if hasMin || hasMax {
+		//JSON Schema measures length in unicode code points
+		item.LenUnit = LenUnitRune
+	}
+
+	used["minLength"] = hasMin
+	used["maxLength"] = hasMax
+
+	if rawPattern, ok := schema.get("pattern"); ok {
+		//ECMA 262 syntax not supported by GO regular expression (e.g. lookahead) is reported
+		if expr, strOK := rawPattern.(string); strOK {
+			if pattern, err := regexp.Compile(expr); err == nil {
+				used["pattern"] = true
+				item.Pattern = pattern
+			}
+		}
+	}
+
+	return item
+}
+
+//parseJSONSchemaLength parse non negative integer value of length keyword
+func parseJSONSchemaLength(schema jsonObject, keyword string) (int, bool) {
+	rawValue, ok := schema.get(keyword)
+	if !ok {
+		return 0, false
+	}
+
+	number, _ := rawValue.(json.Number)
+	length, err := strconv.Atoi(string(number))
+	if err != nil || length < 0 {
+		return 0, false
+	}
+
+	return length, true
+}
+
+//walkNumericRange convert minimum, exclusiveMinimum, maximum and exclusiveMaximum keywords,
+//declaring both inclusive and exclusive limit of the same side is not supported
+func (walker *jsonSchemaWalker) walkNumericRange(schema jsonObject, pointer string, used map[string]bool) numericRange {
+	spec := numericRange{}
+
+	parseLimit := func(keyword string) (decimal.Decimal, bool) {
+		rawValue, ok := schema.get(keyword)
+		if !ok {
+			return decimal.Decimal{}, false
+		}
+
+		number, _ := rawValue.(json.Number)
+		value, err := decimal.NewFromString(string(number))
+		if err != nil {
+			walker.addUnsupported(pointer + "/" + keyword)
+			return decimal.Decimal{}, false
+		}
+
+		used[keyword] = true
+
+		return value, true
+	}
+
+	if value, ok := parseLimit("minimum"); ok {
+		spec.enableMin, spec.min = true, value
+	}
+
+	if value, ok := parseLimit("exclusiveMinimum"); ok {
+		if spec.enableMin {
+			walker.addUnsupported(pointer + "/exclusiveMinimum")
+		} else {
+			spec.enableMin, spec.min, spec.exclusiveMin = true, value, true
+		}
+	}
+
+	if value, ok := parseLimit("maximum"); ok {
+		spec.enableMax, spec.max = true, value
+	}
+
+	if value, ok := parseLimit("exclusiveMaximum"); ok {
+		if spec.enableMax {
+			walker.addUnsupported(pointer + "/exclusiveMaximum")
+		} else {
+			spec.enableMax, spec.max, spec.exclusiveMax = true, value, true
+		}
+	}
+
+	return spec
+}
+
+//reportUnused add every keyword of schema which is neither consumed nor annotation into unsupported list
+func (walker *jsonSchemaWalker) reportUnused(schema jsonObject, pointer string, used map[string]bool) {
+	for _, member := range schema {
+		if used[member.Key] || isJSONSchemaAnnotation(member.Key) {
+			continue
+		}
+
+		walker.addUnsupported(pointer + "/" + member.Key)
+	}
+}
+
+func (walker *jsonSchemaWalker) addUnsupported(pointer string) {
+	walker.unsupported = append(walker.unsupported, pointer)
+}
+
+//parseJSONSchemaPrecision convert multipleOf value (e.g. 0.01) into decimal precision,
+//only power of ten less or equal to 1 is accepted
+func parseJSONSchemaPrecision(rawValue interface{}) (int, bool) {
+	number, numberOK := rawValue.(json.Number)
+	if !numberOK {
+		return 0, false
+	}
+
+	value, err := decimal.NewFromString(string(number))
+	if err != nil {
+		return 0, false
+	}
+
+	for precision := 0; precision <= 20; precision++ {
+		if value.Equal(decimal.New(1, int32(-precision))) {
+			return precision, true
+		}
+	}
+
+	return 0, false
+}
+
+//isJSONSchemaFile check object schema is generated from DxFile
+func isJSONSchemaFile(schema jsonObject) bool {
+	rawProperties, _ := schema.get("properties")
+	properties, ok := rawProperties.(jsonObject)
+	if !ok || len(properties) != 2 {
+		return false
+	}
+
+	for _, key := range []string{"filename", "filepath"} {
+		rawProperty, _ := properties.get(key)
+		property, propertyOK := rawProperty.(jsonObject)
+		if !propertyOK || len(property) != 1 {
+			return false
+		}
+
+		if propertyType, _ := property.get("type"); propertyType != "string" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isJSONSchemaAnnotation(keyword string) bool {
+	return isStringInSlice(keyword, jsonSchemaAnnotations)
+}
+
+func isStringInSlice(value string, slice []string) bool {
+	for _, tmp := range slice {
+		if strings.Compare(tmp, value) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+//get member value by key
+func (object jsonObject) get(key string) (interface{}, bool) {
+	for _, member := range object {
+		if strings.Compare(member.Key, key) == 0 {
+			return member.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+//getBool get boolean member value, second return value is false if member is absent or not boolean
+func (object jsonObject) getBool(key string) (bool, bool) {
+	rawValue, ok := object.get(key)
+	if !ok {
+		return false, false
+	}
+
+	value, boolOK := rawValue.(bool)
+
+	return value, boolOK
+}
+
+//decodeJSONValue decode next JSON value, object is decoded as jsonObject to keep its members order
+func decodeJSONValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch delim := token.(type) {
+	case json.Delim:
+		if delim == '{' {
+			object := jsonObject{}
+
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+
+				value, err := decodeJSONValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+
+				object = append(object, jsonMember{keyToken.(string), value})
+			}
+
+			_, err = decoder.Token()
+			return object, err
+		} else if delim == '[' {
+			arr := []interface{}{}
+
+			for decoder.More() {
+				value, err := decodeJSONValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+
+				arr = append(arr, value)
+			}
+
+			_, err = decoder.Token()
+			return arr, err
+		}
+
+		return nil, fmt.Errorf("unexpected delimiter %s", delim)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,168 @@
+package gxschema
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestParseSchemaFromJSONSchema(t *testing.T) {
+	rawJSON := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id": "urn:dxdoc:733bee1b-f79a-4cb7-b675-842317b994b5:3",
+		"title": "invoice",
+		"description": "sales invoice",
+		"type": "object",
+		"properties": {
+			"docNo": {"type": "string", "minLength": 6, "maxLength": 6},
+			"total": {"type": "number", "multipleOf": 0.01},
+			"isPaid": {"type": "boolean"},
+			"issueDate": {"type": "string", "format": "date"},
+			"createdAt": {"type": "string", "format": "date-time"},
+			"callAfter": {"type": "string", "format": "time"},
+			"attachment": {
+				"type": "object",
+				"properties": {"filename": {"type": "string"}, "filepath": {"type": "string"}},
+				"required": ["filename", "filepath"]
+			},
+			"items": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {
+						"description": {"type": "string"},
+						"qty": {"type": "array", "items": {"type": "integer"}}
+					},
+					"required": ["description"]
+				}
+			}
+		},
+		"required": ["docNo", "total", "issueDate", "items"]
+	}`
+
+	doc, err := ParseSchemaFromJSONSchema(rawJSON)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := &DxDoc{
+		Name:     "invoice",
+		ID:       "733bee1b-f79a-4cb7-b675-842317b994b5",
+		Revision: 3,
+		Items: []DxItem{
+			DxStr{Name: "docNo", EnableLenLimit: true, LenLimit: 6, LenUnit: LenUnitRune},
+			DxDecimal{Name: "total", Precision: 2},
+			DxBool{Name: "isPaid", IsOptional: true},
+			DxDate{Name: "issueDate"},
+			DxDateTime{Name: "createdAt", IsOptional: true, RequireTimezone: true},
+			DxTime{Name: "callAfter", IsOptional: true, RequireTimezone: true},
+			DxFile{Name: "attachment", IsOptional: true},
+			DxSection{Name: "items", IsArray: true, Items: []DxItem{
+				DxStr{Name: "description"},
+				DxInt{Name: "qty", IsOptional: true, IsArray: true},
+			}},
+		},
+	}
+
+	if !reflect.DeepEqual(doc, expected) {
+		t.Errorf("parsed document not tally with [output]:\n%#v\n\n[expected]:\n%#v", doc, expected)
+	}
+}
+
+func TestParseSchemaFromJSONSchema_roundTrip(t *testing.T) {
+	doc := DxDoc{
+		Name:     "order",
+		Revision: 8,
+		ID:       "e8b3bb7e-1c42-4d0e-9f57-0d7f4c6a2a11",
+		IsStrict: true,
+		Items: []DxItem{
+			DxStr{Name: "orderNo", EnableLenLimit: true, LenLimit: 7, LenUnit: LenUnitRune},
+			DxStr{Name: "remark", IsOptional: true, EnableMaxLen: true, MaxLen: 50, LenUnit: LenUnitRune},
+			DxStr{Name: "postcode", Pattern: regexp.MustCompile(`^\d{5}$`)},
+			DxEnum{Name: "status", Options: []DxOption{{Value: "draft"}, {Value: "approved"}}},
+			DxInt{Name: "qty", EnableMin: true, Min: decimal.New(0, 0), ExclusiveMin: true},
+			DxDecimal{Name: "rate", Precision: 3, IsOptional: true,
+				EnableMin: true, Min: decimal.New(-5, -1), EnableMax: true, Max: decimal.New(100, 0)},
+			DxDateTime{Name: "createdAt"},
+			DxDateTime{Name: "approvedAt", IsOptional: true, RequireTimezone: true},
+			DxSection{Name: "customer", IsStrict: true, Items: []DxItem{
+				DxStr{Name: "name"},
+				DxTime{Name: "callAfter", IsArray: true, MinItems: 1, MaxItems: 3, UniqueItems: true},
+			}},
+		},
+	}
+
+	jsonStr, jsonErr := doc.JSONSchema()
+	if jsonErr != nil {
+		t.Error(jsonErr)
+		return
+	}
+
+	parsed, err := ParseSchemaFromJSONSchema(jsonStr)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(*parsed, doc) {
+		t.Errorf("round trip document not tally with [output]:\n%#v\n\n[expected]:\n%#v", *parsed, doc)
+	}
+}
+
+func TestParseSchemaFromJSONSchema_unsupportedKeywords(t *testing.T) {
+	rawJSON := `{
+		"title": "invoice",
+		"type": "object",
+		"properties": {
+			"docNo": {"type": "string", "minLength": 1, "maxLength": -6},
+			"qty": {"type": "integer", "minimum": 1, "exclusiveMinimum": 0},
+			"rate": {"type": "number"},
+			"email": {"type": "string", "format": "email"},
+			"status": {"type": "string", "enum": ["draft", 1]},
+			"sku": {"type": "string", "pattern": "^(?!X)\\w+$"},
+			"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "contains": {"type": "string"}}
+		},
+		"additionalProperties": {"type": "string"}
+	}`
+
+	_, err := ParseSchemaFromJSONSchema(rawJSON)
+
+	keywordErr, ok := err.(*UnsupportedKeywordError)
+	if !ok {
+		t.Errorf("expect *UnsupportedKeywordError but get %v", err)
+		return
+	}
+
+	expected := []string{
+		"#/additionalProperties",
+		"#/properties/docNo/maxLength",
+		"#/properties/email/format",
+		"#/properties/qty/exclusiveMinimum",
+		"#/properties/rate/type",
+		"#/properties/sku/pattern",
+		"#/properties/status/enum",
+		"#/properties/tags/contains",
+	}
+
+	if strings.Join(keywordErr.Keywords, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unsupported keywords not tally with [output]:\n%v\n\n[expected]:\n%v",
+			keywordErr.Keywords, expected)
+	}
+}
+
+func TestParseSchemaFromJSONSchema_expectUndeclaredRequiredFail(t *testing.T) {
+	rawJSON := `{
+		"title": "invoice",
+		"type": "object",
+		"properties": {"docNo": {"type": "string"}},
+		"required": ["docNo", "qyt"]
+	}`
+
+	if _, err := ParseSchemaFromJSONSchema(rawJSON); err == nil {
+		t.Error("Expect error occured, required property 'qyt' is not declared")
+	}
+}
@@ -0,0 +1,313 @@
+package gxschema
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+//Compatibility whether data valid under one document revision is still valid under another
+type Compatibility string
+
+const (
+	CompatibilityFull     Compatibility = "full"     //CompatibilityFull data valid under either revision is valid under the other
+	CompatibilityBackward Compatibility = "backward" //CompatibilityBackward data valid under old revision is valid under new revision
+	CompatibilityForward  Compatibility = "forward"  //CompatibilityForward data valid under new revision is valid under old revision
+	CompatibilityBreaking Compatibility = "breaking" //CompatibilityBreaking neither backward nor forward compatible
+)
+
+//Satisfies is compatibility good enough for required compatibility
+func (compatibility Compatibility) Satisfies(required Compatibility) bool {
+	return required == CompatibilityBreaking || compatibility == CompatibilityFull || compatibility == required
+}
+
+//combine compatibility of two changes which are applied together
+func (compatibility Compatibility) combine(other Compatibility) Compatibility {
+	switch {
+	case compatibility == CompatibilityFull:
+		return other
+	case other == CompatibilityFull || other == compatibility:
+		return compatibility
+	}
+
+	return CompatibilityBreaking
+}
+
+//CompatibilityChange schema change with its compatibility
+type CompatibilityChange struct {
+	SchemaChange
+	Compatibility Compatibility `json:"compatibility"`
+}
+
+func (change CompatibilityChange) String() string {
+	return fmt.Sprintf("[%s] %s", change.Compatibility, change.SchemaChange.String())
+}
+
+//CompatibilityReport compatibility of every change between two document revisions
+type CompatibilityReport struct {
+	Name        string                `json:"name"`
+	OldRevision int                   `json:"oldRevision"`
+	NewRevision int                   `json:"newRevision"`
+	Changes     []CompatibilityChange `json:"changes"`
+}
+
+//Compatibility overall compatibility of all changes
+func (report *CompatibilityReport) Compatibility() Compatibility {
+	result := CompatibilityFull
+
+	for _, change := range report.Changes {
+		result = result.combine(change.Compatibility)
+	}
+
+	return result
+}
+
+//String render report as text, one change per line
+func (report *CompatibilityReport) String() string {
+	result := fmt.Sprintf("%s revision %d -> %d is %s compatible",
+		report.Name, report.OldRevision, report.NewRevision, report.Compatibility())
+
+	for _, change := range report.Changes {
+		result += "\n" + change.String()
+	}
+
+	return result
+}
+
+//CheckCompatibility classify every change from old to new document revision,
+//error is returned when overall compatibility doesn't satisfy required compatibility (e.g. to fail a build);
+//pass CompatibilityBreaking as required to get report only
+func CheckCompatibility(oldDoc *DxDoc, newDoc *DxDoc, required Compatibility) (*CompatibilityReport, error) {
+	diff := Diff(oldDoc, newDoc)
+
+	report := &CompatibilityReport{Name: diff.Name, OldRevision: diff.OldRevision, NewRevision: diff.NewRevision}
+
+	for _, change := range diff.Changes {
+		report.Changes = append(report.Changes,
+			CompatibilityChange{SchemaChange: change, Compatibility: changeCompatibility(change, oldDoc, newDoc)})
+	}
+
+	if !report.Compatibility().Satisfies(required) {
+		var violations []string
+
+		for _, change := range report.Changes {
+			if !change.Compatibility.Satisfies(required) {
+				violations = append(violations, change.String())
+			}
+		}
+
+		return report, fmt.Errorf("%s revision %d -> %d is not %s compatible:\n%s",
+			report.Name, report.OldRevision, report.NewRevision, required, strings.Join(violations, "\n"))
+	}
+
+	return report, nil
+}
+
+//changeCompatibility classify single change, unknown change is treated as breaking
+func changeCompatibility(change SchemaChange, oldDoc *DxDoc, newDoc *DxDoc) Compatibility {
+	switch change.Kind {
+	case ChangeAdded:
+		//undeclared key is ignored by old revision unless it is strict
+		item := findItemByPath(newDoc, change.Path)
+		if item != nil && item.IsValueOptional() {
+			if isParentStrict(oldDoc, change.Path) {
+				return CompatibilityBackward
+			}
+
+			return CompatibilityFull
+		}
+
+		if isParentStrict(oldDoc, change.Path) {
+			return CompatibilityBreaking
+		}
+
+		return CompatibilityForward
+	case ChangeRemoved:
+		//key of removed item is ignored by new revision unless it is strict
+		item := findItemByPath(oldDoc, change.Path)
+		if item != nil && item.IsValueOptional() {
+			if isParentStrict(newDoc, change.Path) {
+				return CompatibilityForward
+			}
+
+			return CompatibilityFull
+		}
+
+		if isParentStrict(newDoc, change.Path) {
+			return CompatibilityBreaking
+		}
+
+		return CompatibilityBackward
+	case ChangeAttribute:
+		return attributeCompatibility(change.Attribute, change.OldValue, change.NewValue)
+	}
+
+	//data type change and item moved to another section
+	return CompatibilityBreaking
+}
+
+//attributeCompatibility classify attribute value change, empty value means attribute is not declared
+func attributeCompatibility(attribute string, oldValue string, newValue string) Compatibility {
+	switch attribute {
+	case "isOptional":
+		if oldValue != "" && newValue == "" {
+			//data stored under old revision may omit the item and has no value to fill in
+			return CompatibilityBreaking
+		}
+
+		return constraintCompatibility(newValue, oldValue)
+	case "ignoreCase":
+		//declared value loosens constraint
+		return constraintCompatibility(newValue, oldValue)
+	case "minLen", "min", "minItems":
+		return boundCompatibility(oldValue, newValue, -1)
+	case "maxLen", "max", "maxItems":
+		return boundCompatibility(oldValue, newValue, 1)
+	case "precision":
+		if result := boundCompatibility(oldValue, newValue, 1); result != CompatibilityForward {
+			return result
+		}
+
+		//data stored under old revision may have more fraction digits which can't be filled in
+		return CompatibilityBreaking
+	case "lenLimit", "pattern", "uniqueItems", "uniqueBy", "exclusiveMin", "exclusiveMax", "requireTimezone",
+		"additionalItems":
+		return constraintCompatibility(oldValue, newValue)
+	case "option":
+		return optionCompatibility(oldValue, newValue)
+	}
+
+	return CompatibilityBreaking
+}
+
+//constraintCompatibility classify constraint which is added (tighter), removed (looser) or replaced
+func constraintCompatibility(oldValue string, newValue string) Compatibility {
+	switch {
+	case oldValue == newValue:
+		return CompatibilityFull
+	case oldValue == "":
+		return CompatibilityForward
+	case newValue == "":
+		return CompatibilityBackward
+	}
+
+	return CompatibilityBreaking
+}
+
+//boundCompatibility classify limit change, direction is 1 for upper limit and -1 for lower limit
+func boundCompatibility(oldValue string, newValue string, direction int) Compatibility {
+	if oldValue == "" || newValue == "" {
+		return constraintCompatibility(oldValue, newValue)
+	}
+
+	result, ok := compareBound(oldValue, newValue)
+	if !ok {
+		return CompatibilityBreaking
+	}
+
+	switch result * direction {
+	case 0:
+		return CompatibilityFull
+	case -1:
+		//limit is relaxed
+		return CompatibilityBackward
+	}
+
+	return CompatibilityForward
+}
+
+var boundTimeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02", "15:04:05Z07:00", "15:04:05"}
+
+//compareBound compare two numeric or temporal limits, return false if they are not comparable
+func compareBound(oldValue string, newValue string) (int, bool) {
+	oldDecimal, oldErr := decimal.NewFromString(oldValue)
+	newDecimal, newErr := decimal.NewFromString(newValue)
+
+	if oldErr == nil && newErr == nil {
+		return oldDecimal.Cmp(newDecimal), true
+	}
+
+	for _, layout := range boundTimeLayouts {
+		oldTime, oldErr := time.Parse(layout, oldValue)
+		newTime, newErr := time.Parse(layout, newValue)
+
+		if oldErr == nil && newErr == nil {
+			switch {
+			case oldTime.Before(newTime):
+				return -1, true
+			case oldTime.After(newTime):
+				return 1, true
+			}
+
+			return 0, true
+		}
+	}
+
+	return 0, false
+}
+
+//optionCompatibility classify change of comma separated enum option values
+func optionCompatibility(oldValue string, newValue string) Compatibility {
+	oldOptions := strings.Split(oldValue, ",")
+	newOptions := strings.Split(newValue, ",")
+
+	isSubset := func(options []string, superset []string) bool {
+		for _, option := range options {
+			if !isStringInSlice(option, superset) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	added := !isSubset(newOptions, oldOptions)
+	removed := !isSubset(oldOptions, newOptions)
+
+	switch {
+	case added && removed:
+		return CompatibilityBreaking
+	case added:
+		return CompatibilityBackward
+	case removed:
+		return CompatibilityForward
+	}
+
+	return CompatibilityFull
+}
+
+//findItemByPath get item by schema path, e.g. /customer/name
+func findItemByPath(doc *DxDoc, path string) DxItem {
+	items := doc.Items
+
+	var item DxItem
+
+	for _, name := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		item = findItemByName(items, name)
+		if item == nil {
+			return nil
+		}
+
+		if section, ok := dereferenceItem(item).(DxSection); ok {
+			items = section.Items
+		} else {
+			items = nil
+		}
+	}
+
+	return item
+}
+
+//isParentStrict is document or section which contains item of path strict
+func isParentStrict(doc *DxDoc, path string) bool {
+	separator := strings.LastIndex(path, "/")
+	if separator <= 0 {
+		return doc.IsStrict
+	}
+
+	section, ok := dereferenceItem(findItemByPath(doc, path[:separator])).(DxSection)
+
+	return ok && section.IsStrict
+}
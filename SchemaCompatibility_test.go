@@ -0,0 +1,202 @@
+package gxschema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	oldDoc, oldErr := ParseSchemaFromXML(`<dxdoc name="invoice" revision="3" id="1">
+	<dxstr name="docNo" maxLen="20"></dxstr>
+	<dxdecimal name="amount" precision="4"></dxdecimal>
+	<dxstr name="remark" isOptional="true"></dxstr>
+	<dxint name="qty" min="1"></dxint>
+	<dxenum name="status">
+		<option value="draft"></option>
+		<option value="paid"></option>
+	</dxenum>
+	<dxdate name="dueDate" max="2030-12-31"></dxdate>
+	<dxsection name="customer" additionalItems="false">
+		<dxstr name="name"></dxstr>
+		<dxstr name="fax" isOptional="true"></dxstr>
+	</dxsection>
+</dxdoc>`)
+	if oldErr != nil {
+		t.Error(oldErr)
+		return
+	}
+
+	testCases := []struct {
+		rawXML   string
+		expected Compatibility
+	}{
+		{ //loosen constraints and add optional item
+			`<dxdoc name="invoice" revision="4" id="1">
+	<dxstr name="docNo" maxLen="30"></dxstr>
+	<dxdecimal name="amount" precision="6"></dxdecimal>
+	<dxstr name="remark" isOptional="true"></dxstr>
+	<dxint name="qty"></dxint>
+	<dxenum name="status">
+		<option value="draft"></option>
+		<option value="paid"></option>
+		<option value="void"></option>
+	</dxenum>
+	<dxdate name="dueDate" max="2035-12-31"></dxdate>
+	<dxstr name="currency" isOptional="true"></dxstr>
+	<dxsection name="customer">
+		<dxstr name="name"></dxstr>
+		<dxstr name="fax" isOptional="true"></dxstr>
+	</dxsection>
+</dxdoc>`,
+			CompatibilityBackward,
+		},
+		{ //tighten constraints and add required item
+			`<dxdoc name="invoice" revision="4" id="1">
+	<dxstr name="docNo" maxLen="10"></dxstr>
+	<dxdecimal name="amount" precision="4"></dxdecimal>
+	<dxstr name="remark" isOptional="true"></dxstr>
+	<dxint name="qty" min="5"></dxint>
+	<dxenum name="status">
+		<option value="paid"></option>
+	</dxenum>
+	<dxdate name="dueDate" max="2025-12-31"></dxdate>
+	<dxstr name="currency"></dxstr>
+	<dxsection name="customer" additionalItems="false">
+		<dxstr name="name"></dxstr>
+	</dxsection>
+</dxdoc>`,
+			CompatibilityForward,
+		},
+		{ //remove optional item from non strict section, unchanged otherwise
+			`<dxdoc name="invoice" revision="4" id="1">
+	<dxstr name="docNo" maxLen="20"></dxstr>
+	<dxdecimal name="amount" precision="4"></dxdecimal>
+	<dxint name="qty" min="1"></dxint>
+	<dxenum name="status">
+		<option value="draft"></option>
+		<option value="paid"></option>
+	</dxenum>
+	<dxdate name="dueDate" max="2030-12-31"></dxdate>
+	<dxsection name="customer" additionalItems="false">
+		<dxstr name="name"></dxstr>
+		<dxstr name="fax" isOptional="true"></dxstr>
+	</dxsection>
+</dxdoc>`,
+			CompatibilityFull,
+		},
+		{ //shrink precision, unchanged otherwise
+			`<dxdoc name="invoice" revision="4" id="1">
+	<dxstr name="docNo" maxLen="20"></dxstr>
+	<dxdecimal name="amount" precision="2"></dxdecimal>
+	<dxstr name="remark" isOptional="true"></dxstr>
+	<dxint name="qty" min="1"></dxint>
+	<dxenum name="status">
+		<option value="draft"></option>
+		<option value="paid"></option>
+	</dxenum>
+	<dxdate name="dueDate" max="2030-12-31"></dxdate>
+	<dxsection name="customer" additionalItems="false">
+		<dxstr name="name"></dxstr>
+		<dxstr name="fax" isOptional="true"></dxstr>
+	</dxsection>
+</dxdoc>`,
+			CompatibilityBreaking,
+		},
+		{ //make optional item required, unchanged otherwise
+			`<dxdoc name="invoice" revision="4" id="1">
+	<dxstr name="docNo" maxLen="20"></dxstr>
+	<dxdecimal name="amount" precision="4"></dxdecimal>
+	<dxstr name="remark"></dxstr>
+	<dxint name="qty" min="1"></dxint>
+	<dxenum name="status">
+		<option value="draft"></option>
+		<option value="paid"></option>
+	</dxenum>
+	<dxdate name="dueDate" max="2030-12-31"></dxdate>
+	<dxsection name="customer" additionalItems="false">
+		<dxstr name="name"></dxstr>
+		<dxstr name="fax" isOptional="true"></dxstr>
+	</dxsection>
+</dxdoc>`,
+			CompatibilityBreaking,
+		},
+		{ //change data type and add required item into strict section
+			`<dxdoc name="invoice" revision="4" id="1">
+	<dxstr name="docNo" maxLen="20"></dxstr>
+	<dxdecimal name="amount" precision="4"></dxdecimal>
+	<dxstr name="remark" isOptional="true"></dxstr>
+	<dxstr name="qty"></dxstr>
+	<dxenum name="status">
+		<option value="draft"></option>
+		<option value="paid"></option>
+	</dxenum>
+	<dxdate name="dueDate" max="2030-12-31"></dxdate>
+	<dxsection name="customer" additionalItems="false">
+		<dxstr name="name"></dxstr>
+		<dxstr name="fax" isOptional="true"></dxstr>
+		<dxstr name="phone"></dxstr>
+	</dxsection>
+</dxdoc>`,
+			CompatibilityBreaking,
+		},
+	}
+
+	for index, testCase := range testCases {
+		newDoc, newErr := ParseSchemaFromXML(testCase.rawXML)
+		if newErr != nil {
+			t.Error(newErr)
+			continue
+		}
+
+		report, err := CheckCompatibility(oldDoc, newDoc, CompatibilityBreaking)
+		if err != nil {
+			t.Errorf("test case %d: expect no error when breaking change is allowed but get %s", index, err.Error())
+			continue
+		}
+
+		if report.Compatibility() != testCase.expected {
+			t.Errorf("test case %d: expect %s compatible but get:\n%s", index, testCase.expected, report.String())
+		}
+
+		for _, change := range report.Changes {
+			if !change.Compatibility.Satisfies(testCase.expected) {
+				t.Errorf("test case %d: expect %s change but get %s", index, testCase.expected, change.String())
+			}
+		}
+
+		_, backwardErr := CheckCompatibility(oldDoc, newDoc, CompatibilityBackward)
+		if (backwardErr == nil) != report.Compatibility().Satisfies(CompatibilityBackward) {
+			t.Errorf("test case %d: expect backward check error only when not backward compatible but get %v",
+				index, backwardErr)
+		}
+	}
+}
+
+func TestCheckCompatibility_expectFail(t *testing.T) {
+	oldDoc := &DxDoc{Name: "invoice", Revision: 1, ID: "1", Items: []DxItem{
+		DxStr{Name: "docNo"},
+		DxDecimal{Name: "amount", Precision: 4},
+	}}
+	newDoc := &DxDoc{Name: "invoice", Revision: 2, ID: "1", Items: []DxItem{
+		DxStr{Name: "docNo"},
+		DxDecimal{Name: "amount", Precision: 2},
+	}}
+
+	report, err := CheckCompatibility(oldDoc, newDoc, CompatibilityBackward)
+	if err == nil {
+		t.Errorf("Expect shrinking precision is not backward compatible:\n%s", report.String())
+		return
+	}
+
+	if !strings.Contains(err.Error(), "[breaking] ~ /amount: precision 4 -> 2") {
+		t.Errorf("Expect error lists offending change but get: %s", err.Error())
+	}
+
+	if _, err := CheckCompatibility(oldDoc, newDoc, CompatibilityForward); err == nil {
+		t.Error("Expect shrinking precision is not forward compatible")
+	}
+
+	if _, err := CheckCompatibility(newDoc, oldDoc, CompatibilityBackward); err != nil {
+		t.Errorf("Expect raising precision is backward compatible but get: %s", err.Error())
+	}
+}
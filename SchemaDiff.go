@@ -0,0 +1,319 @@
+package gxschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+//ChangeKind kind of difference between two DxDoc
+type ChangeKind string
+
+const (
+	ChangeAdded     ChangeKind = "added"     //ChangeAdded item is declared in new document only
+	ChangeRemoved   ChangeKind = "removed"   //ChangeRemoved item is declared in old document only
+	ChangeType      ChangeKind = "type"      //ChangeType item data type (tag name) is changed
+	ChangeAttribute ChangeKind = "attribute" //ChangeAttribute item attribute (e.g. isOptional, lenLimit) is changed
+	ChangeMoved     ChangeKind = "moved"     //ChangeMoved item is moved into another section
+)
+
+//SchemaChange single difference between two DxDoc
+type SchemaChange struct {
+	Kind      ChangeKind `json:"kind"`
+	Path      string     `json:"path"`                //Path item location in old document (new document for added item), e.g. /customer/name; / is document itself
+	NewPath   string     `json:"newPath,omitempty"`   //NewPath item location in new document, moved item only
+	ItemType  string     `json:"itemType,omitempty"`  //ItemType item tag name, e.g. dxstr
+	Attribute string     `json:"attribute,omitempty"` //Attribute changed attribute name, e.g. lenLimit
+	OldValue  string     `json:"oldValue,omitempty"`  //OldValue attribute value or tag name in old document, empty if not declared
+	NewValue  string     `json:"newValue,omitempty"`  //NewValue attribute value or tag name in new document, empty if not declared
+}
+
+func (change SchemaChange) String() string {
+	switch change.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s (%s)", change.Path, change.ItemType)
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s (%s)", change.Path, change.ItemType)
+	case ChangeMoved:
+		return fmt.Sprintf("> %s -> %s (%s)", change.Path, change.NewPath, change.ItemType)
+	case ChangeType:
+		return fmt.Sprintf("~ %s: type %s -> %s", change.Path, change.OldValue, change.NewValue)
+	}
+
+	return fmt.Sprintf("~ %s: %s %s -> %s", change.Path, change.Attribute,
+		diffValue(change.OldValue), diffValue(change.NewValue))
+}
+
+func diffValue(value string) string {
+	if value == "" {
+		return "(unset)"
+	}
+
+	return value
+}
+
+//SchemaDiff differences between two DxDoc
+type SchemaDiff struct {
+	Name        string         `json:"name"`
+	OldRevision int            `json:"oldRevision"`
+	NewRevision int            `json:"newRevision"`
+	Changes     []SchemaChange `json:"changes"`
+}
+
+//HasChanges is there any difference
+func (diff *SchemaDiff) HasChanges() bool { return len(diff.Changes) > 0 }
+
+//String render differences as text, one change per line
+func (diff *SchemaDiff) String() string {
+	result := fmt.Sprintf("%s revision %d -> %d", diff.Name, diff.OldRevision, diff.NewRevision)
+
+	if len(diff.Changes) == 0 {
+		return result + "\nno changes"
+	}
+
+	for _, change := range diff.Changes {
+		result += "\n" + change.String()
+	}
+
+	return result
+}
+
+//JSON render differences as JSON
+func (diff *SchemaDiff) JSON() (string, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "\t")
+
+	tmp := *diff
+	if tmp.Changes == nil {
+		tmp.Changes = []SchemaChange{}
+	}
+
+	if err := encoder.Encode(tmp); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+//Diff compare two revisions of document: added/removed items, data type and attribute changes of items
+//which exist in both, and items moved into another section (same name and data type, different path);
+//items are matched by name within the same section
+func Diff(oldDoc *DxDoc, newDoc *DxDoc) *SchemaDiff {
+	differ := &schemaDiffer{}
+
+	if oldDoc.IsStrict != newDoc.IsStrict {
+		differ.changes = append(differ.changes, SchemaChange{Kind: ChangeAttribute, Path: "/",
+			ItemType: "dxdoc", Attribute: "additionalItems",
+			OldValue: diffStrictValue(oldDoc.IsStrict), NewValue: diffStrictValue(newDoc.IsStrict)})
+	}
+
+	differ.diffItems(oldDoc.Items, newDoc.Items, "")
+	differ.detectMoves()
+
+	return &SchemaDiff{Name: newDoc.Name, OldRevision: oldDoc.Revision, NewRevision: newDoc.Revision,
+		Changes: differ.changes}
+}
+
+func diffStrictValue(strict bool) string {
+	if strict {
+		return "false"
+	}
+
+	return ""
+}
+
+//itemSnapshot tag name and attributes of item, taken from its XML so custom item is compared too
+type itemSnapshot struct {
+	item       DxItem
+	path       string
+	tag        string
+	attributes []xml.Attr
+}
+
+func newItemSnapshot(item DxItem, path string) itemSnapshot {
+	snapshot := itemSnapshot{item: item, path: path}
+
+	var node XMLNode
+	if err := xml.Unmarshal([]byte(item.XML(0)), &node); err != nil {
+		return snapshot
+	}
+
+	snapshot.tag = node.XMLName.Local
+
+	for _, attribute := range node.Attributes {
+		//section type reference is resolved into its items
+		if attribute.Name.Local == "name" || (snapshot.tag == "dxsection" && attribute.Name.Local == "type") {
+			continue
+		}
+
+		snapshot.attributes = append(snapshot.attributes, attribute)
+	}
+
+	//child nodes of non section item (e.g. dxenum options) are compared as single attribute
+	if snapshot.tag != "dxsection" {
+		children := make(map[string][]string)
+		var tags []string
+
+		for _, child := range node.Nodes {
+			if _, ok := children[child.XMLName.Local]; !ok {
+				tags = append(tags, child.XMLName.Local)
+			}
+
+			children[child.XMLName.Local] = append(children[child.XMLName.Local], childValue(&child))
+		}
+
+		for _, tag := range tags {
+			snapshot.attributes = append(snapshot.attributes,
+				xml.Attr{Name: xml.Name{Local: tag}, Value: strings.Join(children[tag], ",")})
+		}
+	}
+
+	return snapshot
+}
+
+//childValue 'value' attribute of child node, or its text if there is no such attribute
+func childValue(node *XMLNode) string {
+	for _, attribute := range node.Attributes {
+		if attribute.Name.Local == "value" {
+			return attribute.Value
+		}
+	}
+
+	return node.Data
+}
+
+func (snapshot itemSnapshot) attribute(name string) string {
+	for _, attribute := range snapshot.attributes {
+		if attribute.Name.Local == name {
+			return attribute.Value
+		}
+	}
+
+	return ""
+}
+
+//schemaDiffer collect changes while walking both documents
+type schemaDiffer struct {
+	changes []SchemaChange
+	removed []itemSnapshot
+	added   []itemSnapshot
+}
+
+func (differ *schemaDiffer) diffItems(oldItems []DxItem, newItems []DxItem, path string) {
+	for _, oldItem := range oldItems {
+		oldSnapshot := newItemSnapshot(oldItem, path+"/"+oldItem.GetName())
+
+		newItem := findItemByName(newItems, oldItem.GetName())
+		if newItem == nil {
+			differ.changes = append(differ.changes, SchemaChange{Kind: ChangeRemoved, Path: oldSnapshot.path,
+				ItemType: oldSnapshot.tag})
+			differ.removed = append(differ.removed, oldSnapshot)
+			continue
+		}
+
+		differ.diffItem(oldSnapshot, newItemSnapshot(newItem, oldSnapshot.path))
+	}
+
+	for _, newItem := range newItems {
+		if findItemByName(oldItems, newItem.GetName()) != nil {
+			continue
+		}
+
+		newSnapshot := newItemSnapshot(newItem, path+"/"+newItem.GetName())
+		differ.changes = append(differ.changes, SchemaChange{Kind: ChangeAdded, Path: newSnapshot.path,
+			ItemType: newSnapshot.tag})
+		differ.added = append(differ.added, newSnapshot)
+	}
+}
+
+//diffItem compare item which exists in both documents, path of change is taken from new snapshot
+func (differ *schemaDiffer) diffItem(oldSnapshot itemSnapshot, newSnapshot itemSnapshot) {
+	if oldSnapshot.tag != newSnapshot.tag {
+		differ.changes = append(differ.changes, SchemaChange{Kind: ChangeType, Path: newSnapshot.path,
+			ItemType: newSnapshot.tag, OldValue: oldSnapshot.tag, NewValue: newSnapshot.tag})
+		return
+	}
+
+	var names []string
+	for _, attribute := range oldSnapshot.attributes {
+		names = append(names, attribute.Name.Local)
+	}
+
+	for _, attribute := range newSnapshot.attributes {
+		if !isStringInSlice(attribute.Name.Local, names) {
+			names = append(names, attribute.Name.Local)
+		}
+	}
+
+	for _, name := range names {
+		oldValue := oldSnapshot.attribute(name)
+		newValue := newSnapshot.attribute(name)
+
+		if oldValue != newValue {
+			differ.changes = append(differ.changes, SchemaChange{Kind: ChangeAttribute, Path: newSnapshot.path,
+				ItemType: newSnapshot.tag, Attribute: name, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	oldSection, oldOK := dereferenceItem(oldSnapshot.item).(DxSection)
+	newSection, newOK := dereferenceItem(newSnapshot.item).(DxSection)
+	if oldOK && newOK {
+		differ.diffItems(oldSection.Items, newSection.Items, newSnapshot.path)
+	}
+}
+
+//detectMoves turn removed and added item pair with same name and data type into single moved change
+func (differ *schemaDiffer) detectMoves() {
+	paired := make(map[string]bool)
+	var moves []SchemaChange
+	var details schemaDiffer
+
+	for _, oldSnapshot := range differ.removed {
+		for _, newSnapshot := range differ.added {
+			if paired["+"+newSnapshot.path] || oldSnapshot.tag != newSnapshot.tag ||
+				oldSnapshot.item.GetName() != newSnapshot.item.GetName() {
+				continue
+			}
+
+			paired["-"+oldSnapshot.path] = true
+			paired["+"+newSnapshot.path] = true
+
+			moves = append(moves, SchemaChange{Kind: ChangeMoved, Path: oldSnapshot.path,
+				NewPath: newSnapshot.path, ItemType: newSnapshot.tag})
+			details.diffItem(oldSnapshot, newSnapshot)
+
+			break
+		}
+	}
+
+	if len(moves) == 0 {
+		return
+	}
+
+	var changes []SchemaChange
+
+	for _, change := range differ.changes {
+		if (change.Kind == ChangeRemoved && paired["-"+change.Path]) ||
+			(change.Kind == ChangeAdded && paired["+"+change.Path]) {
+			continue
+		}
+
+		changes = append(changes, change)
+	}
+
+	differ.changes = append(append(changes, moves...), details.changes...)
+}
+
+func findItemByName(items []DxItem, name string) DxItem {
+	for _, item := range items {
+		if item.GetName() == name {
+			return item
+		}
+	}
+
+	return nil
+}
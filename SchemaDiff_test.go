@@ -0,0 +1,107 @@
+package gxschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	oldDoc, oldErr := ParseSchemaFromXML(`<dxdoc name="invoice" revision="3" id="1">
+	<dxstr name="docNo" lenLimit="20"></dxstr>
+	<dxdecimal name="amount" precision="2"></dxdecimal>
+	<dxstr name="remark" isOptional="true"></dxstr>
+	<dxint name="qty"></dxint>
+	<dxenum name="status">
+		<option value="draft"></option>
+		<option value="paid"></option>
+	</dxenum>
+	<dxsection name="address">
+		<dxstr name="street"></dxstr>
+	</dxsection>
+	<dxsection name="customer">
+		<dxstr name="name"></dxstr>
+	</dxsection>
+</dxdoc>`)
+	if oldErr != nil {
+		t.Error(oldErr)
+		return
+	}
+
+	newDoc, newErr := ParseSchemaFromXML(`<dxdoc name="invoice" revision="4" id="1" additionalItems="false">
+	<dxstr name="docNo" lenLimit="10"></dxstr>
+	<dxdecimal name="amount" precision="4"></dxdecimal>
+	<dxstr name="remark"></dxstr>
+	<dxdecimal name="qty" precision="2"></dxdecimal>
+	<dxenum name="status">
+		<option value="draft"></option>
+		<option value="paid"></option>
+		<option value="void"></option>
+	</dxenum>
+	<dxsection name="customer">
+		<dxstr name="name"></dxstr>
+		<dxstr name="fax" isOptional="true"></dxstr>
+		<dxsection name="address">
+			<dxstr name="street"></dxstr>
+			<dxstr name="postcode"></dxstr>
+		</dxsection>
+	</dxsection>
+</dxdoc>`)
+	if newErr != nil {
+		t.Error(newErr)
+		return
+	}
+
+	diff := Diff(oldDoc, newDoc)
+
+	expectedText := `invoice revision 3 -> 4
+~ /: additionalItems (unset) -> false
+~ /docNo: lenLimit 20 -> 10
+~ /amount: precision 2 -> 4
+~ /remark: isOptional true -> (unset)
+~ /qty: type dxint -> dxdecimal
+~ /status: option draft,paid -> draft,paid,void
++ /customer/fax (dxstr)
+> /address -> /customer/address (dxsection)
++ /customer/address/postcode (dxstr)`
+
+	if diff.String() != expectedText {
+		t.Errorf("Diff text not tally with [output]:\n%s\n\n[expected]:\n%s", diff.String(), expectedText)
+	}
+
+	jsonStr, jsonErr := diff.JSON()
+	if jsonErr != nil {
+		t.Error(jsonErr)
+		return
+	}
+
+	var decoded SchemaDiff
+	if err := json.Unmarshal([]byte(jsonStr), &decoded); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if decoded.OldRevision != 3 || decoded.NewRevision != 4 || len(decoded.Changes) != len(diff.Changes) {
+		t.Errorf("Diff JSON not tally with diff:\n%s", jsonStr)
+	}
+
+	if !strings.Contains(jsonStr, `"kind": "moved"`) || !strings.Contains(jsonStr, `"newPath": "/customer/address"`) {
+		t.Errorf("Expect moved section in diff JSON:\n%s", jsonStr)
+	}
+}
+
+func TestDiff_noChanges(t *testing.T) {
+	doc := &DxDoc{Name: "invoice", Revision: 1, ID: "1", Items: []DxItem{DxStr{Name: "docNo"}}}
+
+	diff := Diff(doc, doc)
+	if diff.HasChanges() {
+		t.Errorf("Expect no changes but get:\n%s", diff.String())
+	}
+
+	jsonStr, jsonErr := diff.JSON()
+	if jsonErr != nil {
+		t.Error(jsonErr)
+	} else if !strings.Contains(jsonStr, `"changes": []`) {
+		t.Errorf("Expect empty change list but get:\n%s", jsonStr)
+	}
+}
@@ -0,0 +1,217 @@
+package gxschema
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+//SchemaFileError error found while loading specific schema file
+type SchemaFileError struct {
+	File string //File path of file which causes the error, relative to Loader file system
+	Err  error
+}
+
+func (err *SchemaFileError) Error() string {
+	return fmt.Sprintf("%s: %s", err.File, err.Err.Error())
+}
+
+//Unwrap get underlying error
+func (err *SchemaFileError) Unwrap() error { return err.Err }
+
+//schemaFragment file included by <dxinclude>
+type schemaFragment struct {
+	node     *XMLNode //node <dxtypes> root node, types are parsed for each document since they are modified by resolveDxTypes
+	includes []string //includes path of files included by this file
+}
+
+//Loader parse schema file from file system; <dxinclude href="..."/> is resolved relative to including file.
+//Included file has <dxtypes> root which declares <dxtype> and <dxinclude>, each file is read once per Loader
+type Loader struct {
+	fsys      fs.FS
+	mutex     sync.Mutex
+	fragments map[string]*schemaFragment
+}
+
+//NewLoader create schema loader which read files from fsys
+func NewLoader(fsys fs.FS) *Loader {
+	return &Loader{fsys: fsys, fragments: make(map[string]*schemaFragment)}
+}
+
+//ParseSchemaFromFile parse document schema (DxDoc) from XML file, included files are resolved relative to it
+//and may be located in its parent directory
+func ParseSchemaFromFile(filename string) (*DxDoc, error) {
+	dir, name := filepath.Split(filename)
+	if dir == "" {
+		dir = "."
+	}
+
+	return NewLoader(osFS(dir)).Load(name)
+}
+
+//osFS read file by OS path relative to directory; unlike os.DirFS, name may refer outside of the directory
+//(e.g. ../shared/types.xml), so ParseSchemaFromFile can resolve include from parent directory
+type osFS string
+
+func (dir osFS) Open(name string) (fs.File, error) {
+	return os.Open(filepath.Join(string(dir), filepath.FromSlash(name)))
+}
+
+//Load parse document schema (DxDoc) from file, types declared in included files are merged into DxDoc.Types;
+//bases provide document referred by 'extends' attribute; error is *SchemaFileError which carries name of file causing the error
+func (loader *Loader) Load(name string, bases ...*DxDoc) (*DxDoc, error) {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+
+	name = path.Clean(name)
+
+	n, err := loader.readFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]bool)
+
+	dxdoc, err := parseDxDocNode(n, func(href string) ([]DxType, error) {
+		target := includePath(name, href)
+
+		if _, err := loader.loadFragment(target, []string{name}); err != nil {
+			return nil, err
+		}
+
+		return loader.collectTypes(target, merged)
+	}, bases)
+	if err != nil {
+		return nil, fileError(name, err)
+	}
+
+	return dxdoc, nil
+}
+
+//readFile read and unmarshal XML file
+func (loader *Loader) readFile(name string) (*XMLNode, error) {
+	raw, err := fs.ReadFile(loader.fsys, name)
+	if err != nil {
+		return nil, &SchemaFileError{File: name, Err: err}
+	}
+
+	var n XMLNode
+	if err := unmarshalXMLNode(string(raw), &n); err != nil {
+		return nil, &SchemaFileError{File: name, Err: err}
+	}
+
+	return &n, nil
+}
+
+//loadFragment read included file and all files it includes, chain is files being included to detect cycle
+func (loader *Loader) loadFragment(name string, chain []string) (*schemaFragment, error) {
+	if isStringInSlice(name, chain) {
+		return nil, fmt.Errorf("include cycle detected: %s -> %s", strings.Join(chain, " -> "), name)
+	}
+
+	if fragment, ok := loader.fragments[name]; ok {
+		return fragment, nil
+	}
+
+	n, err := loader.readFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.XMLName.Local != "dxtypes" {
+		return nil, &SchemaFileError{File: name,
+			Err: fmt.Errorf("expect tag name is dxtypes but get %s instead", n.location(n.XMLName.Local, nil))}
+	}
+
+	fragment := &schemaFragment{node: n}
+
+	for index := range n.Nodes {
+		xmlPath := fmt.Sprintf("dxtypes>%s(%d)", n.Nodes[index].XMLName.Local, index)
+
+		switch n.Nodes[index].XMLName.Local {
+		case "dxtype":
+			//parsed by collectTypes
+		case "dxinclude":
+			href, hrefErr := walkDxInclude(&n.Nodes[index])
+			if hrefErr != nil {
+				return nil, &SchemaFileError{File: name,
+					Err: fmt.Errorf("failed to parse dxinclude at path %s: %s",
+						n.Nodes[index].location(xmlPath, hrefErr), hrefErr.Error())}
+			}
+
+			target := includePath(name, href)
+
+			if _, err := loader.loadFragment(target, append(chain, name)); err != nil {
+				return nil, fileError(name, err)
+			}
+
+			fragment.includes = append(fragment.includes, target)
+		default:
+			return nil, &SchemaFileError{File: name,
+				Err: fmt.Errorf("unknown XML node %s found at path %s",
+					n.Nodes[index].XMLName.Local, n.Nodes[index].location(xmlPath, nil))}
+		}
+	}
+
+	loader.fragments[name] = fragment
+
+	return fragment, nil
+}
+
+//collectTypes parse types of loaded file and files it includes, file which is already merged is skipped
+func (loader *Loader) collectTypes(name string, merged map[string]bool) ([]DxType, error) {
+	if merged[name] {
+		return nil, nil
+	}
+
+	merged[name] = true
+
+	fragment := loader.fragments[name]
+
+	var types []DxType
+
+	for index := range fragment.node.Nodes {
+		node := &fragment.node.Nodes[index]
+		if node.XMLName.Local != "dxtype" {
+			continue
+		}
+
+		dxtype, err := walkDxType(node, fmt.Sprintf("dxtypes>dxtype(%d)", index))
+		if err != nil {
+			return nil, &SchemaFileError{File: name, Err: err}
+		}
+
+		types = append(types, *dxtype)
+	}
+
+	for _, include := range fragment.includes {
+		includeTypes, err := loader.collectTypes(include, merged)
+		if err != nil {
+			return nil, err
+		}
+
+		types = append(types, includeTypes...)
+	}
+
+	return types, nil
+}
+
+//includePath get path of included file, href is relative to including file
+func includePath(from string, href string) string {
+	return path.Join(path.Dir(from), href)
+}
+
+//fileError wrap err with file name, unless err already carries file name
+func fileError(name string, err error) error {
+	var schemaFileErr *SchemaFileError
+	if errors.As(err, &schemaFileErr) {
+		return err
+	}
+
+	return &SchemaFileError{File: name, Err: err}
+}
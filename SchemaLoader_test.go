@@ -0,0 +1,192 @@
+package gxschema
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoader_Load(t *testing.T) {
+	fsys := fstest.MapFS{
+		"invoice.xml": {Data: []byte(`<dxdoc name="invoice" revision="1" id="1">
+	<dxinclude href="shared/address.xml"></dxinclude>
+	<dxinclude href="shared/contact.xml"></dxinclude>
+	<dxstr name="invoiceNo"></dxstr>
+	<dxsection name="billing" type="address"></dxsection>
+	<dxsection name="contact" type="contact"></dxsection>
+</dxdoc>`)},
+		"shared/address.xml": {Data: []byte(`<dxtypes>
+	<dxinclude href="geo.xml"></dxinclude>
+	<dxtype name="address">
+		<dxstr name="street"></dxstr>
+		<dxsection name="geo" isOptional="true" type="geo"></dxsection>
+	</dxtype>
+</dxtypes>`)},
+		"shared/contact.xml": {Data: []byte(`<dxtypes>
+	<dxinclude href="geo.xml"></dxinclude>
+	<dxtype name="contact">
+		<dxstr name="phone"></dxstr>
+	</dxtype>
+</dxtypes>`)},
+		"shared/geo.xml": {Data: []byte(`<dxtypes>
+	<dxtype name="geo">
+		<dxdecimal name="lat" precision="6"></dxdecimal>
+		<dxdecimal name="lng" precision="6"></dxdecimal>
+	</dxtype>
+</dxtypes>`)},
+	}
+
+	loader := NewLoader(fsys)
+
+	for i := 0; i < 2; i++ {
+		dx, err := loader.Load("invoice.xml")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(dx.Types) != 3 {
+			t.Errorf("expect 3 types (geo.xml is merged once) but get %d", len(dx.Types))
+		}
+
+		validateErr := dx.ValidateData(map[string]interface{}{
+			"invoiceNo": "INV001",
+			"billing": map[string]interface{}{"street": "Jalan 1",
+				"geo": map[string]interface{}{"lat": 3.139003, "lng": 101.686855}},
+			"contact": map[string]interface{}{"phone": "0123"},
+		})
+		if validateErr != nil {
+			t.Error(validateErr)
+		}
+	}
+}
+
+func TestLoader_Load_expectFail(t *testing.T) {
+	testCases := []struct {
+		fsys         fstest.MapFS
+		expectedFile string
+	}{
+		{ //include cycle
+			fstest.MapFS{
+				"doc.xml": {Data: []byte(`<dxdoc name="doc" revision="1" id="1"><dxinclude href="a.xml"></dxinclude>` +
+					`<dxstr name="name"></dxstr></dxdoc>`)},
+				"a.xml":     {Data: []byte(`<dxtypes><dxinclude href="lib/b.xml"></dxinclude></dxtypes>`)},
+				"lib/b.xml": {Data: []byte(`<dxtypes><dxinclude href="../a.xml"></dxinclude></dxtypes>`)},
+			},
+			"lib/b.xml",
+		},
+		{ //included file not found
+			fstest.MapFS{
+				"doc.xml": {Data: []byte(`<dxdoc name="doc" revision="1" id="1"><dxinclude href="a.xml"></dxinclude>` +
+					`<dxstr name="name"></dxstr></dxdoc>`)},
+				"a.xml": {Data: []byte(`<dxtypes><dxinclude href="missing.xml"></dxinclude></dxtypes>`)},
+			},
+			"missing.xml",
+		},
+		{ //invalid type in included file
+			fstest.MapFS{
+				"doc.xml": {Data: []byte(`<dxdoc name="doc" revision="1" id="1"><dxinclude href="types/a.xml"></dxinclude>` +
+					`<dxstr name="name"></dxstr></dxdoc>`)},
+				"types/a.xml": {Data: []byte(`<dxtypes><dxtype name="a"><dxint name="qty" isArray="maybe"></dxint></dxtype></dxtypes>`)},
+			},
+			"types/a.xml",
+		},
+		{ //included file is not dxtypes
+			fstest.MapFS{
+				"doc.xml": {Data: []byte(`<dxdoc name="doc" revision="1" id="1"><dxinclude href="a.xml"></dxinclude>` +
+					`<dxstr name="name"></dxstr></dxdoc>`)},
+				"a.xml": {Data: []byte(`<dxdoc name="a" revision="1" id="2"><dxstr name="name"></dxstr></dxdoc>`)},
+			},
+			"a.xml",
+		},
+		{ //type declared in both document and included file
+			fstest.MapFS{
+				"doc.xml": {Data: []byte(`<dxdoc name="doc" revision="1" id="1"><dxinclude href="a.xml"></dxinclude>` +
+					`<dxtype name="a"><dxstr name="name"></dxstr></dxtype><dxstr name="name"></dxstr></dxdoc>`)},
+				"a.xml": {Data: []byte(`<dxtypes><dxtype name="a"><dxstr name="name"></dxstr></dxtype></dxtypes>`)},
+			},
+			"doc.xml",
+		},
+		{ //document includes itself
+			fstest.MapFS{
+				"doc.xml": {Data: []byte(`<dxdoc name="doc" revision="1" id="1"><dxinclude href="doc.xml"></dxinclude>` +
+					`<dxstr name="name"></dxstr></dxdoc>`)},
+			},
+			"doc.xml",
+		},
+	}
+
+	for index, testCase := range testCases {
+		_, err := NewLoader(testCase.fsys).Load("doc.xml")
+
+		var fileErr *SchemaFileError
+		if !errors.As(err, &fileErr) {
+			t.Errorf("test case %d: expect SchemaFileError but get %v", index, err)
+			continue
+		}
+
+		if fileErr.File != testCase.expectedFile {
+			t.Errorf("test case %d: expect error from %s but get: %s", index, testCase.expectedFile, err.Error())
+		}
+	}
+}
+
+func TestParseSchemaFromFile(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "doc.xml"), []byte(`<dxdoc name="doc" revision="1" id="1">`+
+		`<dxinclude href="types.xml"></dxinclude><dxsection name="owner" type="person"></dxsection></dxdoc>`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "types.xml"), []byte(`<dxtypes>`+
+		`<dxtype name="person"><dxstr name="name"></dxstr></dxtype></dxtypes>`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dx, err := ParseSchemaFromFile(filepath.Join(dir, "doc.xml"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := dx.ValidateData(map[string]interface{}{"owner": map[string]interface{}{"name": "John"}}); err != nil {
+		t.Error(err)
+	}
+
+	//include from parent directory
+	if err := os.MkdirAll(filepath.Join(dir, "schemas", "invoice"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "schemas", "invoice", "doc.xml"), []byte(`<dxdoc name="doc" revision="1" id="1">`+
+		`<dxinclude href="../../types.xml"></dxinclude><dxsection name="owner" type="person"></dxsection></dxdoc>`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dx, err = ParseSchemaFromFile(filepath.Join(dir, "schemas", "invoice", "doc.xml"))
+	if err != nil {
+		t.Errorf("expect include from parent directory is resolved but get: %s", err.Error())
+	} else if len(dx.Types) != 1 || dx.Types[0].Name != "person" {
+		t.Errorf("expect type person is included from parent directory but get %v", dx.Types)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "schemas", "invoice", "doc.xml"), []byte(`<dxdoc name="doc" revision="1" id="1">`+
+		`<dxinclude href="../missing.xml"></dxinclude></dxdoc>`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var fileErr *SchemaFileError
+	if _, err := ParseSchemaFromFile(filepath.Join(dir, "schemas", "invoice", "doc.xml")); !errors.As(err, &fileErr) ||
+		fileErr.File != "../missing.xml" {
+		t.Errorf("expect missing included file is reported as ../missing.xml but get %v", err)
+	}
+
+	rawXML := `<dxdoc name="doc" revision="1" id="1"><dxinclude href="types.xml"></dxinclude><dxstr name="name"></dxstr></dxdoc>`
+	if _, err := ParseSchemaFromXML(rawXML); err == nil || !strings.Contains(err.Error(), "Loader") {
+		t.Errorf("expect dxinclude is rejected by ParseSchemaFromXML but get %v", err)
+	}
+}
@@ -3,9 +3,13 @@ package gxschema
 import (
 	"encoding/xml"
 	"fmt"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 var preservedPropertyNames = [4]string{"id", "parent_id", "filename", "filepath"}
@@ -18,6 +22,11 @@ type XMLNode struct {
 	Attributes []xml.Attr
 	Data       string
 	Nodes      []XMLNode
+	Line       int //Line line number of element start tag in XML source, 0 if unknown
+	Column     int //Column column number (in bytes) of element start tag in XML source, 0 if unknown
+
+	offset             int64         //offset byte offset of element start tag in XML source
+	attributePositions []xmlPosition //attributePositions position of every attribute, nil if unknown
 }
 
 //UnmarshalXML unmarshall XML
@@ -26,6 +35,9 @@ func (e *XMLNode) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var nodes []XMLNode
 	var done bool
 	for !done {
+		line, column := d.InputPos()
+		offset := d.InputOffset()
+
 		t, err := d.Token()
 		if err != nil {
 			return err
@@ -34,8 +46,10 @@ func (e *XMLNode) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		case xml.CharData:
 			e.Data = strings.TrimSpace(string(t))
 		case xml.StartElement:
-			e := &XMLNode{}
-			e.UnmarshalXML(d, t)
+			e := &XMLNode{Line: line, Column: column, offset: offset}
+			if err := e.UnmarshalXML(d, t); err != nil {
+				return err
+			}
 			nodes = append(nodes, *e)
 		case xml.EndElement:
 			done = true
@@ -61,88 +75,322 @@ func (e *XMLNode) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	}, start)
 }
 
-//ParseSchemaFromXML parse document schema (DxDoc) from XML string
-func ParseSchemaFromXML(rawXML string) (*DxDoc, error) {
+//ParseSchemaFromXML parse document schema (DxDoc) from XML string,
+//bases provide document referred by <dxdoc extends="name@revision">;
+//use Loader instead when schema contains <dxinclude>
+func ParseSchemaFromXML(rawXML string, bases ...*DxDoc) (*DxDoc, error) {
 	var n XMLNode
 
-	marshallErr := xml.Unmarshal([]byte(rawXML), &n)
+	marshallErr := unmarshalXMLNode(rawXML, &n)
 	if marshallErr != nil {
 		return nil, marshallErr
 	}
 
-	dxdoc, errr := walkDxDoc(&n)
+	return parseDxDocNode(&n, nil, bases)
+}
+
+//includeFunc get types declared in file referred by <dxinclude href>
+type includeFunc func(href string) ([]DxType, error)
+
+//parseDxDocNode parse dxdoc node, <dxinclude> is resolved by include which is nil if not supported;
+//document which extends base document is flattened
+func parseDxDocNode(n *XMLNode, include includeFunc, bases []*DxDoc) (*DxDoc, error) {
+	dxdoc, errr := walkDxDoc(n)
 	if errr != nil {
-		return nil, fmt.Errorf("failed to schema dxdoc: %s", errr.Error())
+		return nil, fmt.Errorf("failed to schema %s: %s", n.location("dxdoc", errr), errr.Error())
 	}
 
-	//travel all sub XML nodes
-	for index, node := range n.Nodes {
-		if strings.Compare(node.XMLName.Local, "dxbool") == 0 {
-			dxbool, boolErr := walkDxBool(&node)
-			if boolErr != nil {
-				return nil, fmt.Errorf(
-					"failed to parse dxbool at path dxdoc>dxbool(%d): %s",
-					index, boolErr.Error())
+	base, baseErr := findBaseDoc(n, bases)
+	if baseErr != nil {
+		return nil, fmt.Errorf("failed to schema %s: %s", n.location("dxdoc", baseErr), baseErr.Error())
+	}
+
+	var removes []string
+
+	locations := newSchemaLocations()
+
+	//travel all sub XML nodes, dxtype, dxinclude and dxremove are only allowed directly under dxdoc
+	for index := range n.Nodes {
+		node := &n.Nodes[index]
+		xmlPath := fmt.Sprintf("dxdoc>%s(%d)", node.XMLName.Local, index)
+
+		switch node.XMLName.Local {
+		case "dxtype":
+			dxtype, typeErr := walkDxType(node, xmlPath)
+			if typeErr != nil {
+				return nil, typeErr
 			}
 
-			dxdoc.Items = append(dxdoc.Items, dxbool)
-		} else if strings.Compare(node.XMLName.Local, "dxint") == 0 {
-			dxint, intErr := walkDxInt(&node)
-			if intErr != nil {
-				return nil, fmt.Errorf(
-					"failed to parse dxint at path dxdoc>dxint(%d): %s",
-					index, intErr.Error())
+			locations.declarations["dxtype:"+dxtype.Name] = node.location(xmlPath, nil)
+			for itemIndex := range dxtype.Items {
+				locations.addSection(dxtype.Items[itemIndex], &node.Nodes[itemIndex],
+					fmt.Sprintf("%s>%s(%d)", xmlPath, node.Nodes[itemIndex].XMLName.Local, itemIndex))
 			}
 
-			dxdoc.Items = append(dxdoc.Items, dxint)
-		} else if strings.Compare(node.XMLName.Local, "dxdecimal") == 0 {
-			dxdecimal, decimalErr := walkDxDecimal(&node)
-			if decimalErr != nil {
-				return nil, fmt.Errorf(
-					"failed to parse dxdecimal at path dxdoc>dxdecimal(%d): %s",
-					index, decimalErr.Error())
+			if err := addDxTypes(dxdoc, []DxType{*dxtype}); err != nil {
+				return nil, fmt.Errorf("failed to parse dxtype at path %s: %s", node.location(xmlPath, err), err.Error())
+			}
+		case "dxinclude":
+			if include == nil {
+				return nil, fmt.Errorf("dxinclude found at path %s requires schema to be parsed by Loader",
+					node.location(xmlPath, nil))
 			}
 
-			dxdoc.Items = append(dxdoc.Items, dxdecimal)
-		} else if strings.Compare(node.XMLName.Local, "dxstr") == 0 {
-			dxstr, strErr := walkDxStr(&node)
-			if strErr != nil {
-				return nil, fmt.Errorf(
-					"failed to parse dxstr at path dxdoc.dxstr(%d): %s",
-					index, strErr.Error())
+			href, hrefErr := walkDxInclude(node)
+			if hrefErr != nil {
+				return nil, fmt.Errorf("failed to parse dxinclude at path %s: %s",
+					node.location(xmlPath, hrefErr), hrefErr.Error())
 			}
 
-			dxdoc.Items = append(dxdoc.Items, dxstr)
-		} else if strings.Compare(node.XMLName.Local, "dxsection") == 0 {
-			dxsection, xmllPath, sectionErr := walkDxSection(&node, fmt.Sprintf("dxdoc>dxsection(%d)", index))
-			if sectionErr != nil {
-				return nil, fmt.Errorf(
-					"failed to parse dxsection at path %s: %s",
-					xmllPath, sectionErr.Error())
+			types, includeErr := include(href)
+			if includeErr != nil {
+				return nil, includeErr
 			}
 
-			dxdoc.Items = append(dxdoc.Items, dxsection)
-		} else if strings.Compare(node.XMLName.Local, "dxfile") == 0 {
-			dxfile, fileErr := walkDxFile(&node)
-			if fileErr != nil {
-				return nil, fmt.Errorf(
-					"failed to parse dxfile at path dxdoc.dxfile(%d): %s",
-					index, fileErr.Error())
+			if err := addDxTypes(dxdoc, types); err != nil {
+				return nil, fmt.Errorf("failed to include %s at path %s: %s", href, node.location(xmlPath, err), err.Error())
+			}
+		case "dxremove":
+			if base == nil {
+				return nil, fmt.Errorf("dxremove found at path %s requires dxdoc to declare 'extends' attribute",
+					node.location(xmlPath, nil))
 			}
 
-			dxdoc.Items = append(dxdoc.Items, dxfile)
-		} else {
-			return nil, fmt.Errorf("unknown XML node %s found", node.XMLName.Local)
+			name, nameErr := walkDxRemove(node)
+			if nameErr != nil {
+				return nil, fmt.Errorf("failed to parse dxremove at path %s: %s",
+					node.location(xmlPath, nameErr), nameErr.Error())
+			}
+
+			locations.declarations["dxremove:"+name] = node.location(xmlPath, nil)
+			removes = append(removes, name)
+		default:
+			item, itemErr := walkDxItem(node, xmlPath)
+			if itemErr != nil {
+				return nil, itemErr
+			}
+
+			locations.declarations["item:"+item.GetName()] = node.location(xmlPath, nil)
+			locations.addSection(item, node, xmlPath)
+			dxdoc.Items = append(dxdoc.Items, item)
+		}
+	}
+
+	if base != nil {
+		if err := extendDxTypes(dxdoc, base); err != nil {
+			return nil, fmt.Errorf("failed to extend %s@%d%s: %s",
+				base.Name, base.Revision, locations.describe(err), err.Error())
+		}
+
+		//typed section must be resolved before it is compared with base item
+		if err := resolveDxTypes(dxdoc, locations.sections); err != nil {
+			return nil, err
+		}
+
+		if err := extendDxDoc(dxdoc, base, removes); err != nil {
+			return nil, fmt.Errorf("failed to extend %s@%d%s: %s",
+				base.Name, base.Revision, locations.describe(err), err.Error())
 		}
 	}
 
 	if len(dxdoc.Items) == 0 {
-		return nil, fmt.Errorf("DxDoc must atleast declare one data type definition")
+		return nil, fmt.Errorf("failed to schema %s: DxDoc must atleast declare one data type definition",
+			n.location("dxdoc", nil))
+	}
+
+	if err := resolveDxTypes(dxdoc, locations.sections); err != nil {
+		return nil, err
 	}
 
 	return dxdoc, nil
 }
 
+//findBaseDoc get base document referred by 'extends' attribute (name@revision), nil if dxdoc extends nothing
+func findBaseDoc(root *XMLNode, bases []*DxDoc) (*DxDoc, error) {
+	for _, attribute := range root.Attributes {
+		if !isAttributeNameMatch(&attribute, "extends") {
+			continue
+		}
+
+		invalidErr := &attributeError{name: attribute.Name,
+			message: fmt.Sprintf("attribute 'extends' expect name@revision but get '%s'", attribute.Value)}
+
+		separator := strings.LastIndex(attribute.Value, "@")
+		if separator <= 0 {
+			return nil, invalidErr
+		}
+
+		name := attribute.Value[:separator]
+		revision, err := strconv.Atoi(attribute.Value[separator+1:])
+		if err != nil {
+			return nil, invalidErr
+		}
+
+		for _, base := range bases {
+			if base != nil && base.Name == name && base.Revision == revision {
+				return base, nil
+			}
+		}
+
+		return nil, &attributeError{name: attribute.Name,
+			message: fmt.Sprintf("base document %s@%d is not provided", name, revision)}
+	}
+
+	return nil, nil
+}
+
+//walkDxRemove get name attribute of dxremove node
+func walkDxRemove(node *XMLNode) (string, error) {
+	for _, attribute := range node.Attributes {
+		if isAttributeNameMatch(&attribute, "name") && attribute.Value != "" {
+			return attribute.Value, nil
+		}
+	}
+
+	return "", fmt.Errorf("missing 'name' attribute")
+}
+
+//extendDxDoc flatten base document items into dxdoc: base item is removed by name listed in removes,
+//item declared by dxdoc replaces base item with same name at same position, otherwise appended after base items
+func extendDxDoc(dxdoc *DxDoc, base *DxDoc, removes []string) error {
+	var items []DxItem
+
+	for _, name := range removes {
+		if _, def := base.findItem(name); def == nil {
+			return &declarationError{key: "dxremove:" + name,
+				message: fmt.Sprintf("removed item '%s' is not declared in base document", name)}
+		}
+
+		if _, def := dxdoc.findItem(name); def != nil {
+			return &declarationError{key: "dxremove:" + name,
+				message: fmt.Sprintf("item '%s' is both removed and declared", name)}
+		}
+	}
+
+	for _, baseItem := range base.Items {
+		if isStringInSlice(baseItem.GetName(), removes) {
+			continue
+		}
+
+		_, item := dxdoc.findItem(baseItem.GetName())
+		if item == nil {
+			items = append(items, baseItem)
+			continue
+		}
+
+		if err := checkItemOverride(baseItem, item); err != nil {
+			return &declarationError{key: "item:" + item.GetName(), message: err.Error()}
+		}
+
+		items = append(items, item)
+	}
+
+	for _, item := range dxdoc.Items {
+		if _, def := base.findItem(item.GetName()); def == nil {
+			items = append(items, item)
+		}
+	}
+
+	dxdoc.Items = items
+
+	return nil
+}
+
+//extendDxTypes prepend base document types into dxdoc, type name must not be declared by both documents
+func extendDxTypes(dxdoc *DxDoc, base *DxDoc) error {
+	var types []DxType
+
+	types = append(types, base.Types...)
+
+	for _, dxtype := range dxdoc.Types {
+		if findDxType(types, dxtype.Name) != nil {
+			return &declarationError{key: "dxtype:" + dxtype.Name,
+				message: fmt.Sprintf("type '%s' is already declared in base document", dxtype.Name)}
+		}
+
+		types = append(types, dxtype)
+	}
+
+	dxdoc.Types = types
+
+	return nil
+}
+
+//checkItemOverride make sure overriding item keeps data type and array-ness of base item and only narrows
+//its constraints (every change is forward compatible), so data accepted by overriding item is accepted by base document
+func checkItemOverride(baseItem DxItem, item DxItem) error {
+	baseType := reflect.TypeOf(dereferenceItem(baseItem))
+	itemType := reflect.TypeOf(dereferenceItem(item))
+
+	if baseType != itemType {
+		return fmt.Errorf("item '%s' can't be overridden from %s to %s", item.GetName(), baseType.Name(), itemType.Name())
+	}
+
+	if baseItem.IsValueArray() != item.IsValueArray() {
+		return fmt.Errorf("item '%s' can't be overridden with different 'isArray'", item.GetName())
+	}
+
+	report, _ := CheckCompatibility(&DxDoc{Items: []DxItem{baseItem}}, &DxDoc{Items: []DxItem{item}},
+		CompatibilityBreaking)
+
+	var loosened []string
+
+	for _, change := range report.Changes {
+		if !change.Compatibility.Satisfies(CompatibilityForward) && !isNarrowingOverride(change.SchemaChange) {
+			loosened = append(loosened, change.String())
+		}
+	}
+
+	if len(loosened) > 0 {
+		return fmt.Errorf("item '%s' override must narrow base item but get: %s",
+			item.GetName(), strings.Join(loosened, ", "))
+	}
+
+	return nil
+}
+
+//isNarrowingOverride is change which is breaking between revisions since stored data can't be fixed up,
+//yet it narrows base item: optional item made required or precision decreased
+func isNarrowingOverride(change SchemaChange) bool {
+	if change.Kind != ChangeAttribute {
+		return false
+	}
+
+	switch change.Attribute {
+	case "isOptional":
+		return change.NewValue == ""
+	case "precision":
+		result, ok := compareBound(change.OldValue, change.NewValue)
+		return ok && result > 0
+	}
+
+	return false
+}
+
+//addDxTypes append types into document, type name must be unique within document
+func addDxTypes(dxdoc *DxDoc, types []DxType) error {
+	for _, dxtype := range types {
+		if findDxType(dxdoc.Types, dxtype.Name) != nil {
+			return fmt.Errorf("type '%s' is declared more than once", dxtype.Name)
+		}
+
+		dxdoc.Types = append(dxdoc.Types, dxtype)
+	}
+
+	return nil
+}
+
+//walkDxInclude get href attribute of dxinclude node
+func walkDxInclude(node *XMLNode) (string, error) {
+	for _, attribute := range node.Attributes {
+		if isAttributeNameMatch(&attribute, "href") && attribute.Value != "" {
+			return attribute.Value, nil
+		}
+	}
+
+	return "", fmt.Errorf("missing 'href' attribute")
+}
+
 func walkDxDoc(root *XMLNode) (*DxDoc, error) {
 	if root.XMLName.Local != "dxdoc" {
 		return nil, fmt.Errorf("expect tag name is dxdoc but get %s instead", root.XMLName.Local)
@@ -159,12 +407,14 @@ func walkDxDoc(root *XMLNode) (*DxDoc, error) {
 	hasID := false
 	var id string
 
+	strict := false
+
 	for _, attribute := range root.Attributes {
 		//check revision attribute
 		if isAttributeNameMatch(&attribute, "revision") {
 			revision, err = parseAttributeInt(&attribute)
 			if err != nil {
-				return nil, fmt.Errorf("<dxdoc> tag %s", err.Error())
+				return nil, fmt.Errorf("<dxdoc> tag %w", err)
 			}
 
 			hasRevision = true
@@ -183,6 +433,15 @@ func walkDxDoc(root *XMLNode) (*DxDoc, error) {
 			id = attribute.Value
 			hasID = true
 		}
+
+		if isAttributeNameMatch(&attribute, "additionalItems") {
+			additional, boolErr := parseAttributeBool(&attribute)
+			if boolErr != nil {
+				return nil, fmt.Errorf("<dxdoc> tag %w", boolErr)
+			}
+
+			strict = !additional
+		}
 	}
 
 	if !hasRevision {
@@ -198,7 +457,7 @@ func walkDxDoc(root *XMLNode) (*DxDoc, error) {
 	}
 
 	//must has attribute 'revision', 'name' and child node(s) 'items'
-	return &DxDoc{Revision: revision, Name: name, ID: id, Items: nil}, nil
+	return &DxDoc{Revision: revision, Name: name, ID: id, Items: nil, IsStrict: strict}, nil
 }
 
 func walkDxBool(node *XMLNode) (*DxBool, error) {
@@ -239,7 +498,13 @@ func walkDxBool(node *XMLNode) (*DxBool, error) {
 		return nil, fmt.Errorf("missing 'name' attribute")
 	}
 
-	return &DxBool{Name: name, IsOptional: optional, IsArray: array}, nil
+	arrSpec, err := walkArraySpec(node, array)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DxBool{Name: name, IsOptional: optional, IsArray: array,
+		MinItems: arrSpec.minItems, MaxItems: arrSpec.maxItems, UniqueItems: arrSpec.uniqueItems}, nil
 }
 
 func walkDxInt(node *XMLNode) (*DxInt, error) {
@@ -280,7 +545,20 @@ func walkDxInt(node *XMLNode) (*DxInt, error) {
 		return nil, fmt.Errorf("missing 'name' attribute")
 	}
 
-	return &DxInt{Name: name, IsOptional: optional, IsArray: array}, nil
+	spec, err := walkNumericRange(node)
+	if err != nil {
+		return nil, err
+	}
+
+	arrSpec, err := walkArraySpec(node, array)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DxInt{Name: name, IsOptional: optional, IsArray: array,
+		MinItems: arrSpec.minItems, MaxItems: arrSpec.maxItems, UniqueItems: arrSpec.uniqueItems,
+		EnableMin: spec.enableMin, Min: spec.min, ExclusiveMin: spec.exclusiveMin,
+		EnableMax: spec.enableMax, Max: spec.max, ExclusiveMax: spec.exclusiveMax}, nil
 }
 
 func walkDxDecimal(node *XMLNode) (*DxDecimal, error) {
@@ -337,153 +615,134 @@ func walkDxDecimal(node *XMLNode) (*DxDecimal, error) {
 		return nil, fmt.Errorf("missing attribute 'precision'")
 	}
 
-	return &DxDecimal{Name: name, IsOptional: optional, IsArray: array, Precision: precision}, nil
-}
+	spec, err := walkNumericRange(node)
+	if err != nil {
+		return nil, err
+	}
 
-func walkDxStr(node *XMLNode) (*DxStr, error) {
-	var err error
+	arrSpec, err := walkArraySpec(node, array)
+	if err != nil {
+		return nil, err
+	}
 
-	hasName := false
-	name := ""
+	return &DxDecimal{Name: name, IsOptional: optional, IsArray: array, Precision: precision,
+		MinItems: arrSpec.minItems, MaxItems: arrSpec.maxItems, UniqueItems: arrSpec.uniqueItems,
+		EnableMin: spec.enableMin, Min: spec.min, ExclusiveMin: spec.exclusiveMin,
+		EnableMax: spec.enableMax, Max: spec.max, ExclusiveMax: spec.exclusiveMax}, nil
+}
 
-	optional := false
-	array := false
+//walkArraySpec parse minItems, maxItems, uniqueItems and uniqueBy attributes,
+//they are only allowed on array item and uniqueBy is only allowed on dxsection
+func walkArraySpec(node *XMLNode, array bool) (arraySpec, error) {
+	var err error
 
-	limit := false
-	len := 0
+	spec := arraySpec{}
 
 	for _, attribute := range node.Attributes {
-		if isAttributeNameMatch(&attribute, "name") {
-			if err := validatePropertyName(attribute.Value); err != nil {
-				return nil, err
+		if isAttributeNameMatch(&attribute, "minItems") {
+			spec.minItems, err = parseAttributeInt(&attribute)
+			if err != nil {
+				return spec, err
 			}
-
-			name = attribute.Value
-			hasName = true
-		}
-
-		if isAttributeNameMatch(&attribute, "isOptional") {
-			optional, err = parseAttributeBool(&attribute)
+		} else if isAttributeNameMatch(&attribute, "maxItems") {
+			spec.maxItems, err = parseAttributeInt(&attribute)
 			if err != nil {
-				return nil, err
+				return spec, err
 			}
-		}
-
-		if isAttributeNameMatch(&attribute, "isArray") {
-			array, err = parseAttributeBool(&attribute)
+		} else if isAttributeNameMatch(&attribute, "uniqueItems") {
+			spec.uniqueItems, err = parseAttributeBool(&attribute)
 			if err != nil {
-				return nil, err
+				return spec, err
+			}
+		} else if isAttributeNameMatch(&attribute, "uniqueBy") {
+			if node.XMLName.Local != "dxsection" {
+				return spec, fmt.Errorf("attribute 'uniqueBy' is only allowed on dxsection")
 			}
-		}
 
-		if isAttributeNameMatch(&attribute, "lenLimit") {
-			limit = true
-			len, err = parseAttributeInt(&attribute)
-			if err != nil {
-				return nil, err
+			for _, field := range strings.Split(attribute.Value, ",") {
+				spec.uniqueBy = append(spec.uniqueBy, strings.TrimSpace(field))
 			}
+		} else {
+			continue
+		}
+
+		if !array {
+			return spec, fmt.Errorf("attribute '%s' requires isArray=\"true\"", attribute.Name.Local)
 		}
 	}
 
-	if !hasName {
-		return nil, fmt.Errorf("missing 'name' attribute")
+	if spec.minItems < 0 || spec.maxItems < 0 {
+		return spec, fmt.Errorf("attribute 'minItems' and 'maxItems' must not be negative")
 	}
 
-	return &DxStr{Name: name, IsOptional: optional, IsArray: array,
-		EnableLenLimit: limit, LenLimit: len}, nil
+	if spec.maxItems > 0 && spec.minItems > spec.maxItems {
+		return spec, fmt.Errorf("attribute 'minItems' (%d) is greater than 'maxItems' (%d)",
+			spec.minItems, spec.maxItems)
+	}
+
+	return spec, nil
 }
 
-func walkDxSection(node *XMLNode, xmlPath string) (*DxSection, string, error) {
+//walkNumericRange parse min, max, exclusiveMin and exclusiveMax attributes of dxint and dxdecimal
+func walkNumericRange(node *XMLNode) (numericRange, error) {
 	var err error
 
-	hasName := false
-	name := ""
-
-	optional := false
-	array := false
+	spec := numericRange{}
 
 	for _, attribute := range node.Attributes {
-		if isAttributeNameMatch(&attribute, "name") {
-			if err := validatePropertyName(attribute.Value); err != nil {
-				return nil, "", err
+		if isAttributeNameMatch(&attribute, "min") {
+			spec.min, err = parseAttributeDecimal(&attribute)
+			if err != nil {
+				return spec, err
 			}
 
-			name = attribute.Value
-			hasName = true
+			spec.enableMin = true
 		}
 
-		if isAttributeNameMatch(&attribute, "isOptional") {
-			optional, err = parseAttributeBool(&attribute)
+		if isAttributeNameMatch(&attribute, "max") {
+			spec.max, err = parseAttributeDecimal(&attribute)
+			if err != nil {
+				return spec, err
+			}
+
+			spec.enableMax = true
+		}
+
+		if isAttributeNameMatch(&attribute, "exclusiveMin") {
+			spec.exclusiveMin, err = parseAttributeBool(&attribute)
 			if err != nil {
-				return nil, xmlPath, err
+				return spec, err
 			}
 		}
 
-		if isAttributeNameMatch(&attribute, "isArray") {
-			array, err = parseAttributeBool(&attribute)
+		if isAttributeNameMatch(&attribute, "exclusiveMax") {
+			spec.exclusiveMax, err = parseAttributeBool(&attribute)
 			if err != nil {
-				return nil, xmlPath, err
+				return spec, err
 			}
 		}
 	}
 
-	if !hasName {
-		return nil, xmlPath, fmt.Errorf("missing 'name' attribute")
+	if spec.exclusiveMin && !spec.enableMin {
+		return spec, fmt.Errorf("attribute 'exclusiveMin' is declared without 'min'")
 	}
 
-	var items []DxItem
-
-	for index, subNode := range node.Nodes {
-		if strings.Compare(node.XMLName.Local, "dxbool") == 0 {
-			dxbool, boolErr := walkDxBool(&subNode)
-			if boolErr != nil {
-				return nil, fmt.Sprintf("%s>dxbool(%d)", xmlPath, index), fmt.Errorf(
-					"failed to parse dxbool, %s", boolErr.Error())
-			}
-
-			items = append(items, dxbool)
-		} else if strings.Compare(node.XMLName.Local, "dxint") == 0 {
-			dxint, intErr := walkDxInt(&subNode)
-			if intErr != nil {
-				return nil, fmt.Sprintf("%s>dxint(%d)", xmlPath, index), fmt.Errorf(
-					"failed to parse dxint, %s", intErr.Error())
-			}
-
-			items = append(items, dxint)
-		} else if strings.Compare(node.XMLName.Local, "dxdecimal") == 0 {
-			dxdecimal, decimalErr := walkDxDecimal(&subNode)
-			if decimalErr != nil {
-				return nil, fmt.Sprintf("%s>dxdecimal(%d)", xmlPath, index), fmt.Errorf(
-					"failed to parse dxdecimal, %s", decimalErr.Error())
-			}
-
-			items = append(items, dxdecimal)
-		} else if strings.Compare(node.XMLName.Local, "dxstr") == 0 {
-			dxstr, strErr := walkDxStr(&subNode)
-			if strErr != nil {
-				return nil, fmt.Sprintf("%s>dxstr(%d)", xmlPath, index), fmt.Errorf(
-					"failed to parse dxstr, %s", strErr.Error())
-			}
-
-			items = append(items, dxstr)
-		} else if strings.Compare(node.XMLName.Local, "dxsection") == 0 {
-			dxSection, xmllPath, sectionErr := walkDxSection(
-				&subNode, fmt.Sprintf("%s>dxsection(%d)", xmlPath, index))
-
-			if sectionErr != nil {
-				return nil, xmllPath, sectionErr
-			}
+	if spec.exclusiveMax && !spec.enableMax {
+		return spec, fmt.Errorf("attribute 'exclusiveMax' is declared without 'max'")
+	}
 
-			items = append(items, dxSection)
-		} else {
-			return nil, xmlPath, fmt.Errorf("unknown XML node")
+	if spec.enableMin && spec.enableMax {
+		cmp := spec.min.Cmp(spec.max)
+		if cmp > 0 || (cmp == 0 && (spec.exclusiveMin || spec.exclusiveMax)) {
+			return spec, fmt.Errorf("attribute min %s and max %s accept no value",
+				spec.min.String(), spec.max.String())
 		}
 	}
 
-	return &DxSection{Name: name, IsOptional: optional, IsArray: array, Items: items}, xmlPath, nil
+	return spec, nil
 }
 
-func walkDxFile(node *XMLNode) (*DxFile, error) {
+func walkDxStr(node *XMLNode) (*DxStr, error) {
 	var err error
 
 	hasName := false
@@ -492,6 +751,19 @@ func walkDxFile(node *XMLNode) (*DxFile, error) {
 	optional := false
 	array := false
 
+	limit := false
+	len := 0
+
+	minLimit := false
+	minLen := 0
+
+	maxLimit := false
+	maxLen := 0
+
+	unit := ""
+
+	var pattern *regexp.Regexp
+
 	for _, attribute := range node.Attributes {
 		if isAttributeNameMatch(&attribute, "name") {
 			if err := validatePropertyName(attribute.Value); err != nil {
@@ -515,21 +787,687 @@ func walkDxFile(node *XMLNode) (*DxFile, error) {
 				return nil, err
 			}
 		}
+
+		if isAttributeNameMatch(&attribute, "lenLimit") {
+			limit = true
+			len, err = parseAttributeInt(&attribute)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if isAttributeNameMatch(&attribute, "minLen") {
+			minLimit = true
+			minLen, err = parseAttributeInt(&attribute)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if isAttributeNameMatch(&attribute, "maxLen") {
+			maxLimit = true
+			maxLen, err = parseAttributeInt(&attribute)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if isAttributeNameMatch(&attribute, "lenUnit") {
+			if !isLenUnitValid(attribute.Value) {
+				return nil, fmt.Errorf("attribute 'lenUnit' expect byte, rune or grapheme but get %s",
+					attribute.Value)
+			}
+
+			unit = attribute.Value
+		}
+
+		if isAttributeNameMatch(&attribute, "pattern") {
+			pattern, err = regexp.Compile(attribute.Value)
+			if err != nil {
+				return nil, fmt.Errorf("attribute 'pattern' is not valid regular expression: %s", err.Error())
+			}
+		}
 	}
 
 	if !hasName {
 		return nil, fmt.Errorf("missing 'name' attribute")
 	}
 
-	return &DxFile{Name: name, IsOptional: optional, IsArray: array}, nil
-}
+	if len < 0 {
+		return nil, fmt.Errorf("attribute 'lenLimit' can't be negative but get %d", len)
+	}
 
-//parseAttributeInt validate XML attribute is matching with provided name and it is integer type
-func parseAttributeInt(attr *xml.Attr) (int, error) {
-	value, err := strconv.Atoi(strings.TrimSpace(attr.Value))
+	if minLen < 0 {
+		return nil, fmt.Errorf("attribute 'minLen' can't be negative but get %d", minLen)
+	}
+
+	if maxLen < 0 {
+		return nil, fmt.Errorf("attribute 'maxLen' can't be negative but get %d", maxLen)
+	}
+
+	if minLimit && maxLimit && minLen > maxLen {
+		return nil, fmt.Errorf("attribute 'minLen' (%d) is greater than 'maxLen' (%d)", minLen, maxLen)
+	}
+
+	arrSpec, err := walkArraySpec(node, array)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DxStr{Name: name, IsOptional: optional, IsArray: array,
+		MinItems: arrSpec.minItems, MaxItems: arrSpec.maxItems, UniqueItems: arrSpec.uniqueItems,
+		EnableLenLimit: limit, LenLimit: len,
+		EnableMinLen: minLimit, MinLen: minLen,
+		EnableMaxLen: maxLimit, MaxLen: maxLen, LenUnit: LenUnit(unit), Pattern: pattern}, nil
+}
+
+//walkDxItems parse every item node, xmlPath is the location of parent node, e.g. dxdoc>dxsection(2)
+func walkDxItems(nodes []XMLNode, xmlPath string) ([]DxItem, error) {
+	var items []DxItem
+
+	for index := range nodes {
+		item, err := walkDxItem(&nodes[index], fmt.Sprintf("%s>%s(%d)", xmlPath, nodes[index].XMLName.Local, index))
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+//walkDxItem parse single item node based on its tag name, dxsection is parsed recursively;
+//tag registered by RegisterItemType is parsed by its registered parser
+func walkDxItem(node *XMLNode, xmlPath string) (DxItem, error) {
+	var item DxItem
+	var err error
+
+	switch node.XMLName.Local {
+	case "dxbool":
+		item, err = walkDxBool(node)
+	case "dxint":
+		item, err = walkDxInt(node)
+	case "dxdecimal":
+		item, err = walkDxDecimal(node)
+	case "dxstr":
+		item, err = walkDxStr(node)
+	case "dxenum":
+		item, err = walkDxEnum(node)
+	case "dxdate":
+		item, err = walkDxDate(node)
+	case "dxtime":
+		item, err = walkDxTime(node)
+	case "dxdatetime":
+		item, err = walkDxDateTime(node)
+	case "dxfile":
+		item, err = walkDxFile(node)
+	case "dxtype", "dxinclude", "dxremove":
+		return nil, fmt.Errorf("%s found at path %s must be declared directly under dxdoc",
+			node.XMLName.Local, node.location(xmlPath, nil))
+	case "dxsection":
+		section, sectionErr := walkDxSection(node, xmlPath)
+		if sectionErr != nil {
+			//child node error already carries its own path
+			return nil, sectionErr
+		}
+
+		return section, nil
+	default:
+		parser, ok := findItemParser(node.XMLName.Local)
+		if !ok {
+			return nil, fmt.Errorf("unknown XML node %s found at path %s", node.XMLName.Local, node.location(xmlPath, nil))
+		}
+
+		item, err = walkCustomItem(node, parser)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s at path %s: %s",
+			node.XMLName.Local, node.location(xmlPath, err), err.Error())
+	}
+
+	return item, nil
+}
+
+func walkDxSection(node *XMLNode, xmlPath string) (*DxSection, error) {
+	section, err := walkDxSectionAttributes(node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dxsection at path %s: %s", node.location(xmlPath, err), err.Error())
+	}
+
+	if section.TypeName != "" {
+		//items are copied from referred type by resolveDxTypes
+		if len(node.Nodes) > 0 {
+			return nil, fmt.Errorf("failed to parse dxsection at path %s: "+
+				"dxsection with attribute 'type' must not declare child items", node.location(xmlPath, nil))
+		}
+
+		return section, nil
+	}
+
+	section.Items, err = walkDxItems(node.Nodes, xmlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkUniqueBy(section); err != nil {
+		return nil, fmt.Errorf("failed to parse dxsection at path %s: %s", node.location(xmlPath, err), err.Error())
+	}
+
+	return section, nil
+}
+
+//checkUniqueBy make sure every uniqueBy field is declared in section
+func checkUniqueBy(section *DxSection) error {
+	for _, field := range section.UniqueBy {
+		if _, def := section.findItem(field); def == nil {
+			return fmt.Errorf("attribute 'uniqueBy' refers to undeclared item '%s'", field)
+		}
+	}
+
+	return nil
+}
+
+func walkDxType(node *XMLNode, xmlPath string) (*DxType, error) {
+	hasName := false
+	name := ""
+
+	for _, attribute := range node.Attributes {
+		if isAttributeNameMatch(&attribute, "name") {
+			if err := validatePropertyName(attribute.Value); err != nil {
+				return nil, fmt.Errorf("failed to parse dxtype at path %s: %s", node.location(xmlPath, err), err.Error())
+			}
+
+			name = attribute.Value
+			hasName = true
+		}
+	}
+
+	if !hasName {
+		return nil, fmt.Errorf("failed to parse dxtype at path %s: missing 'name' attribute", node.location(xmlPath, nil))
+	}
+
+	if len(node.Nodes) == 0 {
+		return nil, fmt.Errorf("failed to parse dxtype at path %s: type '%s' must declare atleast one item",
+			node.location(xmlPath, nil), name)
+	}
+
+	items, err := walkDxItems(node.Nodes, xmlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DxType{Name: name, Items: items}, nil
+}
+
+func findDxType(types []DxType, name string) *DxType {
+	for index := range types {
+		if types[index].Name == name {
+			return &types[index]
+		}
+	}
+
+	return nil
+}
+
+//typeResolver copy items of referred DxType into DxSection, detect type which refers to itself
+type typeResolver struct {
+	types     []DxType
+	resolved  map[string]bool
+	chain     []string              //chain types being resolved, used to detect cycle
+	locations map[*DxSection]string //locations location of typed dxsection in schema XML, used in error message
+}
+
+//resolveDxTypes fill items of every DxSection which refers to DxType, including sections inside types;
+//locations is location of typed dxsection in schema XML, section not found in it is reported by name only
+func resolveDxTypes(doc *DxDoc, locations map[*DxSection]string) error {
+	resolver := &typeResolver{types: doc.Types, resolved: make(map[string]bool), locations: locations}
+
+	for index := range doc.Types {
+		if err := resolver.resolveType(&doc.Types[index]); err != nil {
+			return err
+		}
+	}
+
+	return resolver.resolveItems(doc.Items, "dxdoc")
+}
+
+func (resolver *typeResolver) resolveType(dxtype *DxType) error {
+	if resolver.resolved[dxtype.Name] {
+		return nil
+	}
+
+	resolver.chain = append(resolver.chain, dxtype.Name)
+
+	if err := resolver.resolveItems(dxtype.Items, "dxtype '"+dxtype.Name+"'"); err != nil {
+		return err
+	}
+
+	resolver.chain = resolver.chain[:len(resolver.chain)-1]
+	resolver.resolved[dxtype.Name] = true
+
+	return nil
+}
+
+func (resolver *typeResolver) resolveItems(items []DxItem, owner string) error {
+	for _, item := range items {
+		section, ok := item.(*DxSection)
+		if !ok {
+			continue
+		}
+
+		if section.TypeName == "" {
+			if err := resolver.resolveItems(section.Items, owner); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		dxtype := findDxType(resolver.types, section.TypeName)
+		if dxtype == nil {
+			return fmt.Errorf("dxsection '%s' in %s%s refers to undeclared type '%s'",
+				section.Name, owner, resolver.at(section), section.TypeName)
+		}
+
+		if !resolver.resolved[dxtype.Name] && isStringInSlice(dxtype.Name, resolver.chain) {
+			return fmt.Errorf("dxsection '%s' in %s%s forms type cycle: %s -> %s",
+				section.Name, owner, resolver.at(section), strings.Join(resolver.chain, " -> "), dxtype.Name)
+		}
+
+		if err := resolver.resolveType(dxtype); err != nil {
+			return err
+		}
+
+		section.Items = dxtype.Items
+
+		if err := checkUniqueBy(section); err != nil {
+			return fmt.Errorf("dxsection '%s' in %s%s %s", section.Name, owner, resolver.at(section), err.Error())
+		}
+	}
+
+	return nil
+}
+
+//at describe location of section in schema XML, empty if unknown
+func (resolver *typeResolver) at(section *DxSection) string {
+	if location, ok := resolver.locations[section]; ok {
+		return " at path " + location
+	}
+
+	return ""
+}
+
+//walkDxSectionAttributes parse dxsection attributes, child items are not parsed
+func walkDxSectionAttributes(node *XMLNode) (*DxSection, error) {
+	var err error
+
+	hasName := false
+	name := ""
+
+	optional := false
+	array := false
+	strict := false
+	typeName := ""
+
+	for _, attribute := range node.Attributes {
+		if isAttributeNameMatch(&attribute, "name") {
+			if err := validatePropertyName(attribute.Value); err != nil {
+				return nil, err
+			}
+
+			name = attribute.Value
+			hasName = true
+		}
+
+		if isAttributeNameMatch(&attribute, "isOptional") {
+			optional, err = parseAttributeBool(&attribute)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if isAttributeNameMatch(&attribute, "isArray") {
+			array, err = parseAttributeBool(&attribute)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if isAttributeNameMatch(&attribute, "additionalItems") {
+			additional, boolErr := parseAttributeBool(&attribute)
+			if boolErr != nil {
+				return nil, boolErr
+			}
+
+			strict = !additional
+		}
+
+		if isAttributeNameMatch(&attribute, "type") {
+			if err := validatePropertyName(attribute.Value); err != nil {
+				return nil, err
+			}
+
+			typeName = attribute.Value
+		}
+	}
+
+	if !hasName {
+		return nil, fmt.Errorf("missing 'name' attribute")
+	}
+
+	arrSpec, err := walkArraySpec(node, array)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DxSection{Name: name, IsOptional: optional, IsArray: array, IsStrict: strict,
+		MinItems: arrSpec.minItems, MaxItems: arrSpec.maxItems, UniqueItems: arrSpec.uniqueItems,
+		UniqueBy: arrSpec.uniqueBy, TypeName: typeName}, nil
+}
+
+func walkDxFile(node *XMLNode) (*DxFile, error) {
+	var err error
+
+	hasName := false
+	name := ""
+
+	optional := false
+	array := false
+
+	for _, attribute := range node.Attributes {
+		if isAttributeNameMatch(&attribute, "name") {
+			if err := validatePropertyName(attribute.Value); err != nil {
+				return nil, err
+			}
+
+			name = attribute.Value
+			hasName = true
+		}
+
+		if isAttributeNameMatch(&attribute, "isOptional") {
+			optional, err = parseAttributeBool(&attribute)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if isAttributeNameMatch(&attribute, "isArray") {
+			array, err = parseAttributeBool(&attribute)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !hasName {
+		return nil, fmt.Errorf("missing 'name' attribute")
+	}
+
+	arrSpec, err := walkArraySpec(node, array)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DxFile{Name: name, IsOptional: optional, IsArray: array,
+		MinItems: arrSpec.minItems, MaxItems: arrSpec.maxItems, UniqueItems: arrSpec.uniqueItems}, nil
+}
+
+func walkDxEnum(node *XMLNode) (*DxEnum, error) {
+	var err error
+
+	hasName := false
+	name := ""
+
+	optional := false
+	array := false
+	ignoreCase := false
+
+	for _, attribute := range node.Attributes {
+		if isAttributeNameMatch(&attribute, "name") {
+			if err := validatePropertyName(attribute.Value); err != nil {
+				return nil, err
+			}
+
+			name = attribute.Value
+			hasName = true
+		}
+
+		if isAttributeNameMatch(&attribute, "isOptional") {
+			optional, err = parseAttributeBool(&attribute)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if isAttributeNameMatch(&attribute, "isArray") {
+			array, err = parseAttributeBool(&attribute)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if isAttributeNameMatch(&attribute, "ignoreCase") {
+			ignoreCase, err = parseAttributeBool(&attribute)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !hasName {
+		return nil, fmt.Errorf("missing 'name' attribute")
+	}
+
+	arrSpec, err := walkArraySpec(node, array)
+	if err != nil {
+		return nil, err
+	}
+
+	enum := &DxEnum{Name: name, IsOptional: optional, IsArray: array, IgnoreCase: ignoreCase,
+		MinItems: arrSpec.minItems, MaxItems: arrSpec.maxItems, UniqueItems: arrSpec.uniqueItems}
+
+	for index, subNode := range node.Nodes {
+		if strings.Compare(subNode.XMLName.Local, "option") != 0 {
+			return nil, fmt.Errorf("expect <option> but get <%s> at option(%d)", subNode.XMLName.Local, index)
+		}
+
+		hasValue := false
+		option := DxOption{}
+
+		for _, attribute := range subNode.Attributes {
+			if isAttributeNameMatch(&attribute, "value") {
+				option.Value = attribute.Value
+				hasValue = true
+			}
+
+			if isAttributeNameMatch(&attribute, "label") {
+				option.Label = attribute.Value
+			}
+		}
+
+		if !hasValue {
+			return nil, fmt.Errorf("missing 'value' attribute at option(%d)", index)
+		}
+
+		if enum.findOption(option.Value) >= 0 {
+			return nil, fmt.Errorf("duplicate option value '%s' at option(%d)", option.Value, index)
+		}
+
+		enum.Options = append(enum.Options, option)
+	}
+
+	if len(enum.Options) == 0 {
+		return nil, fmt.Errorf("must atleast declare one <option>")
+	}
+
+	return enum, nil
+}
+
+func walkDxDate(node *XMLNode) (*DxDate, error) {
+	name, optional, array, spec, err := walkTemporal(node, temporalDate)
+	if err != nil {
+		return nil, err
+	}
+
+	arrSpec, err := walkArraySpec(node, array)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DxDate{Name: name, IsOptional: optional, IsArray: array,
+		MinItems: arrSpec.minItems, MaxItems: arrSpec.maxItems, UniqueItems: arrSpec.uniqueItems,
+		Format: spec.format, Min: spec.min, Max: spec.max}, nil
+}
+
+func walkDxTime(node *XMLNode) (*DxTime, error) {
+	name, optional, array, spec, err := walkTemporal(node, temporalTime)
+	if err != nil {
+		return nil, err
+	}
+
+	arrSpec, err := walkArraySpec(node, array)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DxTime{Name: name, IsOptional: optional, IsArray: array,
+		MinItems: arrSpec.minItems, MaxItems: arrSpec.maxItems, UniqueItems: arrSpec.uniqueItems,
+		Format: spec.format, Min: spec.min, Max: spec.max,
+		RequireTimezone: spec.requireTimezone}, nil
+}
+
+func walkDxDateTime(node *XMLNode) (*DxDateTime, error) {
+	name, optional, array, spec, err := walkTemporal(node, temporalDateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	arrSpec, err := walkArraySpec(node, array)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DxDateTime{Name: name, IsOptional: optional, IsArray: array,
+		MinItems: arrSpec.minItems, MaxItems: arrSpec.maxItems, UniqueItems: arrSpec.uniqueItems,
+		Format: spec.format, Min: spec.min, Max: spec.max,
+		RequireTimezone: spec.requireTimezone}, nil
+}
+
+//walkTemporal parse attributes shared by dxdate, dxtime and dxdatetime
+func walkTemporal(node *XMLNode, kind temporalKind) (string, bool, bool, temporalSpec, error) {
+	var err error
+
+	spec := temporalSpec{kind: kind}
+
+	hasName := false
+	name := ""
+
+	optional := false
+	array := false
+
+	var minAttr, maxAttr *xml.Attr
+
+	for index, attribute := range node.Attributes {
+		if isAttributeNameMatch(&attribute, "name") {
+			if err := validatePropertyName(attribute.Value); err != nil {
+				return "", false, false, spec, err
+			}
+
+			name = attribute.Value
+			hasName = true
+		}
+
+		if isAttributeNameMatch(&attribute, "isOptional") {
+			optional, err = parseAttributeBool(&attribute)
+			if err != nil {
+				return "", false, false, spec, err
+			}
+		}
+
+		if isAttributeNameMatch(&attribute, "isArray") {
+			array, err = parseAttributeBool(&attribute)
+			if err != nil {
+				return "", false, false, spec, err
+			}
+		}
+
+		if isAttributeNameMatch(&attribute, "format") {
+			spec.format = attribute.Value
+		}
+
+		if kind != temporalDate && isAttributeNameMatch(&attribute, "requireTimezone") {
+			spec.requireTimezone, err = parseAttributeBool(&attribute)
+			if err != nil {
+				return "", false, false, spec, err
+			}
+		}
+
+		//min and max depend on format, parse them after all attributes are read
+		if isAttributeNameMatch(&attribute, "min") {
+			minAttr = &node.Attributes[index]
+		}
+
+		if isAttributeNameMatch(&attribute, "max") {
+			maxAttr = &node.Attributes[index]
+		}
+	}
+
+	if !hasName {
+		return "", false, false, spec, fmt.Errorf("missing 'name' attribute")
+	}
+
+	if minAttr != nil {
+		spec.min, err = parseAttributeTemporal(minAttr, spec)
+		if err != nil {
+			return "", false, false, spec, err
+		}
+	}
+
+	if maxAttr != nil {
+		spec.max, err = parseAttributeTemporal(maxAttr, spec)
+		if err != nil {
+			return "", false, false, spec, err
+		}
+	}
+
+	if minAttr != nil && maxAttr != nil && spec.compare(spec.min, spec.max) > 0 {
+		return "", false, false, spec, fmt.Errorf("attribute min %s is later than max %s",
+			minAttr.Value, maxAttr.Value)
+	}
+
+	return name, optional, array, spec, nil
+}
+
+//parseAttributeInt validate XML attribute is matching with provided name and it is integer type
+func parseAttributeInt(attr *xml.Attr) (int, error) {
+	value, err := strconv.Atoi(strings.TrimSpace(attr.Value))
+	if err != nil {
+		return 0, &attributeError{name: attr.Name, message: fmt.Sprintf(
+			"unable to parse int from attribute %s, attribute value: %s", attr.Name.Local, strings.TrimSpace(attr.Value))}
+	}
+
+	return value, nil
+}
+
+//parseAttributeTemporal parse date, time or datetime from attribute based on data type definition
+func parseAttributeTemporal(attr *xml.Attr, spec temporalSpec) (time.Time, error) {
+	value, _, err := spec.parse(strings.TrimSpace(attr.Value))
+	if err != nil {
+		return time.Time{}, &attributeError{name: attr.Name, message: fmt.Sprintf(
+			"unable to parse %s from attribute %s, attribute value: %s",
+			spec.kind, attr.Name.Local, strings.TrimSpace(attr.Value))}
+	}
+
+	return value, nil
+}
+
+func parseAttributeDecimal(attr *xml.Attr) (decimal.Decimal, error) {
+	value, err := decimal.NewFromString(strings.TrimSpace(attr.Value))
 	if err != nil {
-		return 0, fmt.Errorf("unable to parse int from attribute %s, attribute value: %s",
-			attr.Name.Local, strings.TrimSpace(attr.Value))
+		return decimal.Decimal{}, &attributeError{name: attr.Name, message: fmt.Sprintf(
+			"unable to parse decimal from attribute %s, attribute value: %s", attr.Name.Local, strings.TrimSpace(attr.Value))}
 	}
 
 	return value, nil
@@ -544,8 +1482,8 @@ func parseAttributeBool(attr *xml.Attr) (bool, error) {
 		return false, nil
 	}
 
-	return false, fmt.Errorf("unable to parse bool from attribute %s, attribute value: %s",
-		attr.Name.Local, attr.Value)
+	return false, &attributeError{name: attr.Name, message: fmt.Sprintf(
+		"unable to parse bool from attribute %s, attribute value: %s", attr.Name.Local, attr.Value)}
 }
 
 func isAttributeNameMatch(attr *xml.Attr, name string) bool {
@@ -3,6 +3,8 @@ package gxschema
 import (
 	"strings"
 	"testing"
+
+	"github.com/shopspring/decimal"
 )
 
 func TestParseSchemaFromXML_expectKeywordIDFail(t *testing.T) {
@@ -189,3 +191,799 @@ func TestWalkDxDoc(t *testing.T) {
 		t.Errorf("Expect has 6 items definition but get %d instead", len(dx.Items))
 	}
 }
+
+func TestParseSchemaFromXML_dateTime(t *testing.T) {
+	rawXML := `<?xml version="1.0"?>
+<dxdoc name="booking" revision="1" id="1a9f6a8e-4ab2-4d35-8b7e-9d4f1c2b3a10">
+	<dxdate name="checkIn" min="2018-01-01" max="2018-12-31"></dxdate>
+	<dxdate name="holidays" isArray="true" isOptional="true" format="02/01/2006"></dxdate>
+	<dxtime name="arrival" requireTimezone="true"></dxtime>
+	<dxdatetime name="createdAt" min="2018-01-01T00:00:00Z" requireTimezone="true"></dxdatetime>
+</dxdoc>`
+
+	dx, dxErr := ParseSchemaFromXML(rawXML)
+	if dxErr != nil {
+		t.Error(dxErr)
+		return
+	}
+
+	xmlStr, xmlErr := dx.XML()
+	if xmlErr != nil {
+		t.Error(xmlErr)
+		return
+	}
+
+	if strings.Compare(xmlStr, rawXML) != 0 {
+		t.Errorf("XML output not tally with [output]: \n%s\n\n[expected]:\n%s", xmlStr, rawXML)
+	}
+
+	validateErr := dx.ValidateData(map[string]interface{}{
+		"checkIn":   "2018-06-07",
+		"holidays":  []interface{}{"25/12/2018"},
+		"arrival":   "14:00:00+08:00",
+		"createdAt": "2018-06-01T10:00:00+08:00",
+	})
+	if validateErr != nil {
+		t.Error(validateErr)
+	}
+}
+
+func TestParseSchemaFromXML_expectDateRangeFail(t *testing.T) {
+	rawXML := `<?xml version="1.0"?>
+	<dxdoc name="booking" revision="1" id="1a9f6a8e-4ab2-4d35-8b7e-9d4f1c2b3a10">
+		<dxdate name="checkIn" min="2018-12-31" max="2018-01-01"></dxdate>
+	</dxdoc>`
+
+	_, dxErr := ParseSchemaFromXML(rawXML)
+	if dxErr == nil {
+		t.Error("Expect error occured due to min is later than max")
+	}
+}
+
+func TestParseSchemaFromXML_strict(t *testing.T) {
+	rawXML := `<?xml version="1.0"?>
+<dxdoc name="booking" revision="1" id="1a9f6a8e-4ab2-4d35-8b7e-9d4f1c2b3a10" additionalItems="false">
+	<dxsection name="stay" additionalItems="false">
+		<dxdate name="checkIn"></dxdate>
+	</dxsection>
+</dxdoc>`
+
+	dx, dxErr := ParseSchemaFromXML(rawXML)
+	if dxErr != nil {
+		t.Error(dxErr)
+		return
+	}
+
+	xmlStr, xmlErr := dx.XML()
+	if xmlErr != nil {
+		t.Error(xmlErr)
+		return
+	}
+
+	if strings.Compare(xmlStr, rawXML) != 0 {
+		t.Errorf("XML output not tally with [output]: \n%s\n\n[expected]:\n%s", xmlStr, rawXML)
+	}
+
+	validateErr := dx.ValidateData(map[string]interface{}{
+		"stay": map[string]interface{}{"checkIn": "2018-06-07", "checkOut": "2018-06-09"},
+	})
+	if validateErr == nil {
+		t.Error("Expect error occured due to undeclared key 'checkOut'")
+	}
+}
+
+func TestParseSchemaFromXML_strLength(t *testing.T) {
+	rawXML := `<?xml version="1.0"?>
+<dxdoc name="customer" revision="1" id="6c1f0a52-93b4-4b3e-a0f1-2e5d7c9b8a41">
+	<dxstr name="code" lenLimit="6" lenUnit="byte"></dxstr>
+	<dxstr name="surname" minLen="1" maxLen="50" lenUnit="rune"></dxstr>
+	<dxstr name="nickname" isOptional="true" maxLen="10" lenUnit="grapheme"></dxstr>
+</dxdoc>`
+
+	dx, dxErr := ParseSchemaFromXML(rawXML)
+	if dxErr != nil {
+		t.Error(dxErr)
+		return
+	}
+
+	xmlStr, xmlErr := dx.XML()
+	if xmlErr != nil {
+		t.Error(xmlErr)
+		return
+	}
+
+	if strings.Compare(xmlStr, rawXML) != 0 {
+		t.Errorf("XML output not tally with [output]: \n%s\n\n[expected]:\n%s", xmlStr, rawXML)
+	}
+
+	validateErr := dx.ValidateData(map[string]interface{}{"code": "cust01", "surname": "Müller"})
+	if validateErr != nil {
+		t.Error(validateErr)
+	}
+}
+
+func TestParseSchemaFromXML_expectStrLengthFail(t *testing.T) {
+	rawXMLs := []string{
+		`<dxdoc name="customer" revision="1" id="1"><dxstr name="code" minLen="5" maxLen="2"></dxstr></dxdoc>`,
+		`<dxdoc name="customer" revision="1" id="1"><dxstr name="code" lenUnit="char"></dxstr></dxdoc>`,
+		`<dxdoc name="customer" revision="1" id="1"><dxstr name="code" lenLimit="-1"></dxstr></dxdoc>`,
+		`<dxdoc name="customer" revision="1" id="1"><dxstr name="code" minLen="-1"></dxstr></dxdoc>`,
+		`<dxdoc name="customer" revision="1" id="1"><dxstr name="code" maxLen="-5"></dxstr></dxdoc>`,
+		`<dxdoc name="customer" revision="1" id="1"><dxstr name="code" minLen="-3" maxLen="-1"></dxstr></dxdoc>`,
+	}
+
+	for _, rawXML := range rawXMLs {
+		if _, dxErr := ParseSchemaFromXML(rawXML); dxErr == nil {
+			t.Errorf("Expect error occured on invalid length definition: %s", rawXML)
+		}
+	}
+}
+
+func TestParseSchemaFromXML_strPattern(t *testing.T) {
+	rawXML := `<?xml version="1.0"?>
+<dxdoc name="order" revision="1" id="3d5e0c6b-7a0f-4f25-9c1e-8b2a4d6f0e17">
+	<dxstr name="orderNo" pattern="^ODR\d{4}$"></dxstr>
+	<dxstr name="postcodes" isArray="true" pattern="^[0-9]{5}(-[0-9]{4})?$"></dxstr>
+</dxdoc>`
+
+	dx, dxErr := ParseSchemaFromXML(rawXML)
+	if dxErr != nil {
+		t.Error(dxErr)
+		return
+	}
+
+	xmlStr, xmlErr := dx.XML()
+	if xmlErr != nil {
+		t.Error(xmlErr)
+		return
+	}
+
+	if strings.Compare(xmlStr, rawXML) != 0 {
+		t.Errorf("XML output not tally with [output]: \n%s\n\n[expected]:\n%s", xmlStr, rawXML)
+	}
+
+	validateErr := dx.ValidateData(map[string]interface{}{
+		"orderNo":   "ODR0001",
+		"postcodes": []interface{}{"12345", "12345-6789"},
+	})
+	if validateErr != nil {
+		t.Error(validateErr)
+	}
+
+	validateErr = dx.ValidateData(map[string]interface{}{
+		"orderNo":   "ODR0001",
+		"postcodes": []interface{}{"12345", "1234"},
+	})
+	if validateErr == nil {
+		t.Error("Expect error occured due to postcode not match pattern")
+	}
+}
+
+func TestParseSchemaFromXML_expectPatternFail(t *testing.T) {
+	rawXML := `<dxdoc name="order" revision="1" id="1"><dxstr name="orderNo" pattern="ODR(\d"></dxstr></dxdoc>`
+
+	if _, dxErr := ParseSchemaFromXML(rawXML); dxErr == nil {
+		t.Error("Expect error occured due to invalid regular expression")
+	}
+}
+
+func TestParseSchemaFromXML_enum(t *testing.T) {
+	rawXML := `<?xml version="1.0"?>
+<dxdoc name="invoice" revision="1" id="5f8e2d1c-0b3a-4c6d-9e7f-1a2b3c4d5e6f">
+	<dxenum name="status">
+		<option value="draft" label="Draft"></option>
+		<option value="approved" label="Approved &amp; Locked"></option>
+		<option value="void"></option>
+	</dxenum>
+	<dxenum name="tags" isArray="true" isOptional="true" ignoreCase="true">
+		<option value="urgent"></option>
+		<option value="export"></option>
+	</dxenum>
+</dxdoc>`
+
+	dx, dxErr := ParseSchemaFromXML(rawXML)
+	if dxErr != nil {
+		t.Error(dxErr)
+		return
+	}
+
+	xmlStr, xmlErr := dx.XML()
+	if xmlErr != nil {
+		t.Error(xmlErr)
+		return
+	}
+
+	if strings.Compare(xmlStr, rawXML) != 0 {
+		t.Errorf("XML output not tally with [output]: \n%s\n\n[expected]:\n%s", xmlStr, rawXML)
+	}
+
+	validateErr := dx.ValidateData(map[string]interface{}{
+		"status": "approved",
+		"tags":   []interface{}{"URGENT"},
+	})
+	if validateErr != nil {
+		t.Error(validateErr)
+	}
+}
+
+func TestParseSchemaFromXML_expectEnumFail(t *testing.T) {
+	rawXMLs := []string{
+		`<dxdoc name="invoice" revision="1" id="1"><dxenum name="status"></dxenum></dxdoc>`,
+		`<dxdoc name="invoice" revision="1" id="1"><dxenum name="status"><option label="Draft"></option></dxenum></dxdoc>`,
+		`<dxdoc name="invoice" revision="1" id="1"><dxenum name="status"><item value="draft"></item></dxenum></dxdoc>`,
+		`<dxdoc name="invoice" revision="1" id="1"><dxenum name="status" ignoreCase="true">` +
+			`<option value="draft"></option><option value="DRAFT"></option></dxenum></dxdoc>`,
+	}
+
+	for _, rawXML := range rawXMLs {
+		if _, dxErr := ParseSchemaFromXML(rawXML); dxErr == nil {
+			t.Errorf("Expect error occured on invalid dxenum definition: %s", rawXML)
+		}
+	}
+}
+
+func TestParseSchemaFromXML_numericRange(t *testing.T) {
+	rawXML := `<?xml version="1.0"?>
+<dxdoc name="order" revision="1" id="9b2c4e6a-1d3f-4a5b-8c7d-0e1f2a3b4c5d">
+	<dxint name="qty" min="0" exclusiveMin="true"></dxint>
+	<dxint name="ratings" isArray="true" min="1" max="5"></dxint>
+	<dxdecimal name="discount" precision="2" min="0" max="100"></dxdecimal>
+	<dxdecimal name="rate" precision="4" max="0.5" exclusiveMax="true"></dxdecimal>
+</dxdoc>`
+
+	dx, dxErr := ParseSchemaFromXML(rawXML)
+	if dxErr != nil {
+		t.Error(dxErr)
+		return
+	}
+
+	xmlStr, xmlErr := dx.XML()
+	if xmlErr != nil {
+		t.Error(xmlErr)
+		return
+	}
+
+	if strings.Compare(xmlStr, rawXML) != 0 {
+		t.Errorf("XML output not tally with [output]: \n%s\n\n[expected]:\n%s", xmlStr, rawXML)
+	}
+
+	validateErr := dx.ValidateData(map[string]interface{}{
+		"qty":      1,
+		"ratings":  []interface{}{1, 5},
+		"discount": 12.5,
+		"rate":     0.4999,
+	})
+	if validateErr != nil {
+		t.Error(validateErr)
+	}
+
+	validateErr = dx.ValidateData(map[string]interface{}{
+		"qty":      1,
+		"ratings":  []interface{}{1, 6},
+		"discount": 12.5,
+		"rate":     0.4999,
+	})
+	if validateErr == nil {
+		t.Error("Expect error occured due to rating greater than 5")
+	}
+}
+
+func TestParseSchemaFromXML_expectNumericRangeFail(t *testing.T) {
+	rawXMLs := []string{
+		`<dxdoc name="order" revision="1" id="1"><dxint name="qty" min="abc"></dxint></dxdoc>`,
+		`<dxdoc name="order" revision="1" id="1"><dxint name="qty" min="10" max="1"></dxint></dxdoc>`,
+		`<dxdoc name="order" revision="1" id="1"><dxint name="qty" exclusiveMin="true"></dxint></dxdoc>`,
+		`<dxdoc name="order" revision="1" id="1"><dxdecimal name="rate" precision="2" min="1" max="1" exclusiveMax="true"></dxdecimal></dxdoc>`,
+	}
+
+	for _, rawXML := range rawXMLs {
+		if _, dxErr := ParseSchemaFromXML(rawXML); dxErr == nil {
+			t.Errorf("Expect error occured on invalid range definition: %s", rawXML)
+		}
+	}
+}
+
+func TestParseSchemaFromXML_arrayConstraints(t *testing.T) {
+	rawXML := `<?xml version="1.0"?>
+<dxdoc name="order" revision="1" id="2c4e6a8b-3d5f-4b7a-9c1e-2f4a6b8c0d1e">
+	<dxstr name="tags" isArray="true" isOptional="true" maxItems="5" uniqueItems="true"></dxstr>
+	<dxsection name="items" isArray="true" minItems="1" maxItems="200" uniqueBy="sku">
+		<dxenum name="sku">
+			<option value="A1"></option>
+			<option value="B2"></option>
+		</dxenum>
+	</dxsection>
+</dxdoc>`
+
+	dx, dxErr := ParseSchemaFromXML(rawXML)
+	if dxErr != nil {
+		t.Error(dxErr)
+		return
+	}
+
+	xmlStr, xmlErr := dx.XML()
+	if xmlErr != nil {
+		t.Error(xmlErr)
+		return
+	}
+
+	if strings.Compare(xmlStr, rawXML) != 0 {
+		t.Errorf("XML output not tally with [output]: \n%s\n\n[expected]:\n%s", xmlStr, rawXML)
+	}
+}
+
+func TestParseSchemaFromXML_expectArrayConstraintFail(t *testing.T) {
+	rawXMLs := []string{
+		`<dxdoc name="order" revision="1" id="1"><dxstr name="tags" maxItems="5"></dxstr></dxdoc>`,
+		`<dxdoc name="order" revision="1" id="1"><dxstr name="tags" isArray="true" minItems="5" maxItems="2"></dxstr></dxdoc>`,
+		`<dxdoc name="order" revision="1" id="1"><dxstr name="tags" isArray="true" minItems="-1"></dxstr></dxdoc>`,
+		`<dxdoc name="order" revision="1" id="1"><dxstr name="tags" isArray="true" uniqueBy="sku"></dxstr></dxdoc>`,
+		`<dxdoc name="order" revision="1" id="1"><dxsection name="items" isArray="true" uniqueBy="sku">` +
+			`<dxsection name="qty"></dxsection></dxsection></dxdoc>`,
+	}
+
+	for _, rawXML := range rawXMLs {
+		if _, dxErr := ParseSchemaFromXML(rawXML); dxErr == nil {
+			t.Errorf("Expect error occured on invalid array constraint: %s", rawXML)
+		}
+	}
+}
+
+func TestParseSchemaFromXML_nestedSection(t *testing.T) {
+	rawXML := `<?xml version="1.0"?>
+<dxdoc name="order" revision="2" id="4e6a8c0d-5f7b-4d9e-8a2c-3b5d7f9a1c3e">
+	<dxstr name="orderNo" lenLimit="7"></dxstr>
+	<dxsection name="customer">
+		<dxstr name="name"></dxstr>
+		<dxbool name="isMember"></dxbool>
+		<dxsection name="address" isOptional="true">
+			<dxstr name="street"></dxstr>
+			<dxint name="floor" isOptional="true"></dxint>
+			<dxsection name="geo">
+				<dxdecimal name="lat" precision="6"></dxdecimal>
+				<dxdecimal name="lng" precision="6"></dxdecimal>
+			</dxsection>
+		</dxsection>
+	</dxsection>
+	<dxsection name="items" isArray="true">
+		<dxstr name="sku"></dxstr>
+		<dxint name="qty"></dxint>
+		<dxfile name="photos" isArray="true" isOptional="true"></dxfile>
+		<dxsection name="batches" isArray="true" isOptional="true">
+			<dxstr name="batchNo"></dxstr>
+			<dxdate name="expiry"></dxdate>
+		</dxsection>
+	</dxsection>
+</dxdoc>`
+
+	dx, dxErr := ParseSchemaFromXML(rawXML)
+	if dxErr != nil {
+		t.Error(dxErr)
+		return
+	}
+
+	xmlStr, xmlErr := dx.XML()
+	if xmlErr != nil {
+		t.Error(xmlErr)
+		return
+	}
+
+	if strings.Compare(xmlStr, rawXML) != 0 {
+		t.Errorf("XML output not tally with [output]: \n%s\n\n[expected]:\n%s", xmlStr, rawXML)
+	}
+
+	validateErr := dx.ValidateData(map[string]interface{}{
+		"orderNo": "ODR0001",
+		"customer": map[string]interface{}{
+			"name":     "John",
+			"isMember": true,
+			"address": map[string]interface{}{
+				"street": "Jalan 1",
+				"geo":    map[string]interface{}{"lat": 3.139003, "lng": 101.686855},
+			},
+		},
+		"items": []interface{}{
+			map[string]interface{}{
+				"sku":     "A1",
+				"qty":     2,
+				"photos":  []interface{}{map[string]interface{}{"filename": "a.png", "filepath": "/tmp/a.png"}},
+				"batches": []interface{}{map[string]interface{}{"batchNo": "B01", "expiry": "2019-01-31"}},
+			},
+		},
+	})
+	if validateErr != nil {
+		t.Error(validateErr)
+	}
+
+	validateErr = dx.ValidateData(map[string]interface{}{
+		"orderNo":  "ODR0001",
+		"customer": map[string]interface{}{"name": "John", "isMember": "yes"},
+		"items":    []interface{}{},
+	})
+	if validateErr == nil {
+		t.Error("Expect error occured due to nested isMember is not boolean")
+	}
+}
+
+func TestParseSchemaFromXML_expectNestedItemFail(t *testing.T) {
+	rawXML := `<dxdoc name="order" revision="1" id="1">
+		<dxstr name="orderNo"></dxstr>
+		<dxsection name="customer">
+			<dxsection name="address">
+				<dxint name="floor" isArray="maybe"></dxint>
+			</dxsection>
+		</dxsection>
+	</dxdoc>`
+
+	_, dxErr := ParseSchemaFromXML(rawXML)
+	if dxErr == nil {
+		t.Error("Expect error occured due to invalid isArray value")
+		return
+	}
+
+	if !strings.Contains(dxErr.Error(), "dxdoc>dxsection(1)>dxsection(0)>dxint(0)") {
+		t.Errorf("Expect error message contains path of invalid node but get: %s", dxErr.Error())
+	}
+
+	rawXML = `<dxdoc name="order" revision="1" id="1"><dxsection name="customer"><dxtext name="name"></dxtext></dxsection></dxdoc>`
+
+	if _, dxErr := ParseSchemaFromXML(rawXML); dxErr == nil {
+		t.Error("Expect error occured due to unknown nested node")
+	}
+}
+
+func TestParseSchemaFromXML_types(t *testing.T) {
+	rawXML := `<?xml version="1.0"?>
+<dxdoc name="order" revision="3" id="2c4e6a8b-0d1f-4b3c-9e5a-7c9e1b3d5f7a">
+	<dxtype name="geo">
+		<dxdecimal name="lat" precision="6"></dxdecimal>
+		<dxdecimal name="lng" precision="6"></dxdecimal>
+	</dxtype>
+	<dxtype name="address">
+		<dxstr name="street"></dxstr>
+		<dxstr name="postcode" lenLimit="5"></dxstr>
+		<dxsection name="geo" isOptional="true" type="geo"></dxsection>
+	</dxtype>
+	<dxstr name="orderNo"></dxstr>
+	<dxsection name="billing" type="address"></dxsection>
+	<dxsection name="shipping" isArray="true" uniqueBy="postcode" type="address"></dxsection>
+	<dxsection name="supplier" isOptional="true">
+		<dxstr name="name"></dxstr>
+		<dxsection name="address" additionalItems="false" type="address"></dxsection>
+	</dxsection>
+</dxdoc>`
+
+	dx, dxErr := ParseSchemaFromXML(rawXML)
+	if dxErr != nil {
+		t.Error(dxErr)
+		return
+	}
+
+	xmlStr, xmlErr := dx.XML()
+	if xmlErr != nil {
+		t.Error(xmlErr)
+		return
+	}
+
+	if strings.Compare(xmlStr, rawXML) != 0 {
+		t.Errorf("XML output not tally with [output]: \n%s\n\n[expected]:\n%s", xmlStr, rawXML)
+	}
+
+	address := map[string]interface{}{"street": "Jalan 1", "postcode": "50450",
+		"geo": map[string]interface{}{"lat": 3.139003, "lng": 101.686855}}
+
+	validateErr := dx.ValidateData(map[string]interface{}{
+		"orderNo":  "ODR0001",
+		"billing":  address,
+		"shipping": []interface{}{address},
+		"supplier": map[string]interface{}{"name": "ABC", "address": address},
+	})
+	if validateErr != nil {
+		t.Error(validateErr)
+	}
+
+	report := dx.ValidateAll(map[string]interface{}{
+		"orderNo":  "ODR0001",
+		"billing":  map[string]interface{}{"street": "Jalan 1", "postcode": "504"},
+		"shipping": []interface{}{address, address},
+		"supplier": map[string]interface{}{"name": "ABC",
+			"address": map[string]interface{}{"street": "Jalan 1", "postcode": "50450", "unit": "A"}},
+	})
+
+	expectedPaths := []string{"/billing/postcode", "/shipping/1", "/supplier/address/unit"}
+	for _, path := range expectedPaths {
+		found := false
+		for _, violation := range report.Errors {
+			found = found || violation.Path == path
+		}
+
+		if !found {
+			t.Errorf("Expect violation at %s but get:\n%s", path, report.Error())
+		}
+	}
+}
+
+func TestParseSchemaFromXML_expectTypeFail(t *testing.T) {
+	rawXMLs := []string{
+		//undeclared type
+		`<dxdoc name="order" revision="1" id="1"><dxsection name="billing" type="address"></dxsection></dxdoc>`,
+		//type refers to itself
+		`<dxdoc name="order" revision="1" id="1"><dxtype name="node"><dxstr name="name"></dxstr>` +
+			`<dxsection name="child" isOptional="true" type="node"></dxsection></dxtype>` +
+			`<dxsection name="root" type="node"></dxsection></dxdoc>`,
+		//types refer to each other
+		`<dxdoc name="order" revision="1" id="1"><dxtype name="a"><dxsection name="b" type="b"></dxsection></dxtype>` +
+			`<dxtype name="b"><dxsection name="a" type="a"></dxsection></dxtype>` +
+			`<dxstr name="orderNo"></dxstr></dxdoc>`,
+		//section declares both type and child items
+		`<dxdoc name="order" revision="1" id="1"><dxtype name="address"><dxstr name="street"></dxstr></dxtype>` +
+			`<dxsection name="billing" type="address"><dxstr name="unit"></dxstr></dxsection></dxdoc>`,
+		//type declared twice
+		`<dxdoc name="order" revision="1" id="1"><dxtype name="address"><dxstr name="street"></dxstr></dxtype>` +
+			`<dxtype name="address"><dxstr name="city"></dxstr></dxtype><dxstr name="orderNo"></dxstr></dxdoc>`,
+		//type without item
+		`<dxdoc name="order" revision="1" id="1"><dxtype name="address"></dxtype><dxstr name="orderNo"></dxstr></dxdoc>`,
+		//type declared inside section
+		`<dxdoc name="order" revision="1" id="1"><dxsection name="billing">` +
+			`<dxtype name="address"><dxstr name="street"></dxstr></dxtype></dxsection></dxdoc>`,
+		//uniqueBy refers to item not declared in type
+		`<dxdoc name="order" revision="1" id="1"><dxtype name="address"><dxstr name="street"></dxstr></dxtype>` +
+			`<dxsection name="shipping" isArray="true" uniqueBy="postcode" type="address"></dxsection></dxdoc>`,
+	}
+
+	for _, rawXML := range rawXMLs {
+		if _, dxErr := ParseSchemaFromXML(rawXML); dxErr == nil {
+			t.Errorf("Expect error occured on invalid type: %s", rawXML)
+		}
+	}
+
+	rawXML := `<dxdoc name="order" revision="1" id="1"><dxtype name="a"><dxsection name="b" type="b"></dxsection></dxtype>` +
+		`<dxtype name="b"><dxsection name="a" type="a"></dxsection></dxtype><dxstr name="orderNo"></dxstr></dxdoc>`
+	if _, dxErr := ParseSchemaFromXML(rawXML); dxErr == nil || !strings.Contains(dxErr.Error(), "a -> b -> a") {
+		t.Errorf("Expect error message shows type cycle but get: %v", dxErr)
+	}
+}
+
+func TestParseSchemaFromXML_extends(t *testing.T) {
+	base, baseErr := ParseSchemaFromXML(`<dxdoc name="invoice" revision="2" id="1">
+	<dxtype name="party">
+		<dxstr name="name"></dxstr>
+	</dxtype>
+	<dxstr name="docNo" maxLen="20"></dxstr>
+	<dxsection name="customer" type="party"></dxsection>
+	<dxdecimal name="amount" precision="2"></dxdecimal>
+	<dxstr name="remark" isOptional="true"></dxstr>
+	<dxdate name="dueDate"></dxdate>
+</dxdoc>`)
+	if baseErr != nil {
+		t.Error(baseErr)
+		return
+	}
+
+	rawXML := `<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2">
+	<dxremove name="dueDate"></dxremove>
+	<dxstr name="docNo" maxLen="10"></dxstr>
+	<dxstr name="invoiceNo"></dxstr>
+	<dxsection name="approver" type="party"></dxsection>
+</dxdoc>`
+
+	dx, dxErr := ParseSchemaFromXML(rawXML, base)
+	if dxErr != nil {
+		t.Error(dxErr)
+		return
+	}
+
+	expectedXML := `<?xml version="1.0"?>
+<dxdoc name="creditNote" revision="1" id="2">
+	<dxtype name="party">
+		<dxstr name="name"></dxstr>
+	</dxtype>
+	<dxstr name="docNo" maxLen="10"></dxstr>
+	<dxsection name="customer" type="party"></dxsection>
+	<dxdecimal name="amount" precision="2"></dxdecimal>
+	<dxstr name="remark" isOptional="true"></dxstr>
+	<dxstr name="invoiceNo"></dxstr>
+	<dxsection name="approver" type="party"></dxsection>
+</dxdoc>`
+
+	xmlStr, xmlErr := dx.XML()
+	if xmlErr != nil {
+		t.Error(xmlErr)
+		return
+	}
+
+	if strings.Compare(xmlStr, expectedXML) != 0 {
+		t.Errorf("XML output not tally with [output]: \n%s\n\n[expected]:\n%s", xmlStr, expectedXML)
+	}
+
+	validateErr := dx.ValidateData(map[string]interface{}{
+		"docNo":     "CN00000001",
+		"customer":  map[string]interface{}{"name": "ABC"},
+		"amount":    10.5,
+		"invoiceNo": "INV001",
+		"approver":  map[string]interface{}{"name": "John"},
+	})
+	if validateErr != nil {
+		t.Error(validateErr)
+	}
+
+	if len(base.Items) != 5 {
+		t.Errorf("Expect base document is not modified but it has %d items", len(base.Items))
+	}
+}
+
+func TestParseSchemaFromXML_expectExtendsFail(t *testing.T) {
+	base := &DxDoc{Name: "invoice", Revision: 2, ID: "1", Items: []DxItem{
+		DxStr{Name: "docNo"},
+		DxInt{Name: "lines", IsArray: true},
+	}}
+
+	rawXMLs := []string{
+		//base revision not provided
+		`<dxdoc name="creditNote" revision="1" id="2" extends="invoice@1"><dxstr name="invoiceNo"></dxstr></dxdoc>`,
+		//malformed extends
+		`<dxdoc name="creditNote" revision="1" id="2" extends="invoice"><dxstr name="invoiceNo"></dxstr></dxdoc>`,
+		//override changes data type
+		`<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2"><dxint name="docNo"></dxint></dxdoc>`,
+		//override changes isArray
+		`<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2"><dxint name="lines"></dxint></dxdoc>`,
+		//remove undeclared item
+		`<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2"><dxremove name="dueDate"></dxremove></dxdoc>`,
+		//remove and override same item
+		`<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2"><dxremove name="docNo"></dxremove>` +
+			`<dxstr name="docNo"></dxstr></dxdoc>`,
+		//remove without extends
+		`<dxdoc name="creditNote" revision="1" id="2"><dxremove name="docNo"></dxremove><dxstr name="docNo"></dxstr></dxdoc>`,
+		//remove every item
+		`<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2"><dxremove name="docNo"></dxremove>` +
+			`<dxremove name="lines"></dxremove></dxdoc>`,
+	}
+
+	for _, rawXML := range rawXMLs {
+		if _, dxErr := ParseSchemaFromXML(rawXML, base); dxErr == nil {
+			t.Errorf("Expect error occured on invalid extends: %s", rawXML)
+		}
+	}
+}
+
+func TestParseSchemaFromXML_extendsOverride(t *testing.T) {
+	base, baseErr := ParseSchemaFromXML(`<dxdoc name="invoice" revision="2" id="1">
+	<dxtype name="party">
+		<dxstr name="name"></dxstr>
+		<dxstr name="phone" isOptional="true"></dxstr>
+	</dxtype>
+	<dxsection name="customer" type="party"></dxsection>
+	<dxstr name="remark" isOptional="true"></dxstr>
+	<dxdecimal name="amount" precision="4"></dxdecimal>
+</dxdoc>`)
+	if baseErr != nil {
+		t.Error(baseErr)
+		return
+	}
+
+	rawXMLs := []string{
+		//typed section identical to base
+		`<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2">` +
+			`<dxsection name="customer" type="party"></dxsection></dxdoc>`,
+		//typed section refers to narrower type of derived document
+		`<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2">` +
+			`<dxtype name="contact"><dxstr name="name" maxLen="50"></dxstr><dxstr name="phone"></dxstr></dxtype>` +
+			`<dxsection name="customer" type="contact"></dxsection></dxdoc>`,
+		//optional item made required
+		`<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2"><dxstr name="remark"></dxstr></dxdoc>`,
+		//precision decreased
+		`<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2">` +
+			`<dxdecimal name="amount" precision="2"></dxdecimal></dxdoc>`,
+	}
+
+	for _, rawXML := range rawXMLs {
+		if _, dxErr := ParseSchemaFromXML(rawXML, base); dxErr != nil {
+			t.Errorf("Expect narrowing override is accepted: %s, but get %s", rawXML, dxErr.Error())
+		}
+	}
+
+	//typed section which loosens base
+	looseXML := `<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2">` +
+		`<dxtype name="contact"><dxstr name="name" isOptional="true"></dxstr></dxtype>` +
+		`<dxsection name="customer" type="contact"></dxsection></dxdoc>`
+	if _, dxErr := ParseSchemaFromXML(looseXML, base); dxErr == nil || !strings.Contains(dxErr.Error(), "narrow") {
+		t.Errorf("Expect loosening typed section override is rejected but get %v", dxErr)
+	}
+}
+
+func TestParseSchemaFromXML_expectLooseOverrideFail(t *testing.T) {
+	base := &DxDoc{Name: "invoice", Revision: 2, ID: "1", Items: []DxItem{
+		DxStr{Name: "docNo", EnableLenLimit: true, LenLimit: 10},
+		DxInt{Name: "qty", EnableMin: true, Min: decimal.New(1, 0), EnableMax: true, Max: decimal.New(100, 0)},
+		DxStr{Name: "remark"},
+	}}
+
+	//narrowing override is accepted
+	narrowXML := `<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2">` +
+		`<dxstr name="docNo" lenLimit="10" pattern="^CN"></dxstr><dxint name="qty" min="1" max="50"></dxint></dxdoc>`
+	if _, dxErr := ParseSchemaFromXML(narrowXML, base); dxErr != nil {
+		t.Errorf("Expect narrowing override is accepted but get: %s", dxErr.Error())
+	}
+
+	rawXMLs := []string{
+		//drop lenLimit
+		`<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2"><dxstr name="docNo"></dxstr></dxdoc>`,
+		//widen max
+		`<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2"><dxint name="qty" min="1" max="500"></dxint></dxdoc>`,
+		//make required item optional
+		`<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2"><dxstr name="remark" isOptional="true"></dxstr></dxdoc>`,
+	}
+
+	for _, rawXML := range rawXMLs {
+		if _, dxErr := ParseSchemaFromXML(rawXML, base); dxErr == nil || !strings.Contains(dxErr.Error(), "narrow") {
+			t.Errorf("Expect loosening override is rejected: %s, but get %v", rawXML, dxErr)
+		}
+	}
+}
+
+func TestParseSchemaFromXML_errorPosition(t *testing.T) {
+	base := &DxDoc{Name: "invoice", Revision: 2, ID: "1", Items: []DxItem{DxStr{Name: "docNo"}}}
+
+	tests := []struct {
+		name     string
+		rawXML   string
+		position string
+	}{
+		{
+			name: "invalid attribute value",
+			rawXML: `<dxdoc name="order" revision="1" id="1">
+<dxstr name="orderNo"></dxstr>
+<dxsection name="customer">
+  <dxint name="floor" isArray="maybe"></dxint>
+</dxsection>
+</dxdoc>`,
+			position: "line 4, column 23",
+		},
+		{
+			name: "unknown element",
+			rawXML: `<dxdoc name="order" revision="1" id="1">
+<dxstr name="orderNo"></dxstr>
+<dxtext name="remark"></dxtext>
+</dxdoc>`,
+			position: "line 3, column 1",
+		},
+		{
+			name: "invalid dxdoc attribute",
+			rawXML: `<?xml version="1.0"?>
+<dxdoc name="order" revision="one" id="1">
+<dxstr name="orderNo"></dxstr>
+</dxdoc>`,
+			position: "line 2, column 21",
+		},
+		{
+			name: "undeclared type",
+			rawXML: `<dxdoc name="order" revision="1" id="1">
+	<dxstr name="orderNo"></dxstr>
+	<dxsection name="billing" type="address"></dxsection>
+</dxdoc>`,
+			position: "line 3, column 2",
+		},
+		{
+			name: "remove undeclared item",
+			rawXML: `<dxdoc name="creditNote" revision="1" id="2" extends="invoice@2">
+	<dxstr name="invoiceNo"></dxstr>
+	<dxremove name="dueDate"></dxremove>
+</dxdoc>`,
+			position: "line 3, column 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, dxErr := ParseSchemaFromXML(tt.rawXML, base)
+			if dxErr == nil {
+				t.Error("Expect error occured on invalid schema")
+				return
+			}
+
+			if !strings.Contains(dxErr.Error(), tt.position) {
+				t.Errorf("Expect error message contains %s but get: %s", tt.position, dxErr.Error())
+			}
+		})
+	}
+}
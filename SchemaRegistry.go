@@ -0,0 +1,336 @@
+package gxschema
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//ErrSchemaNotFound document ID or revision is not published in registry
+var ErrSchemaNotFound = errors.New("schema not found")
+
+//ErrSchemaRetired document revision is retired, data shall no longer conform to it
+var ErrSchemaRetired = errors.New("schema revision is retired")
+
+//SchemaRegistry store published DxDoc by ID and revision; published revision can't be overwritten
+//and new revision must be greater than every published revision of the same ID
+type SchemaRegistry interface {
+	Publish(doc *DxDoc) error                        //Publish store document as its ID and revision
+	Get(id string, revision int) (*DxDoc, error)     //Get pinned revision of document
+	Latest(id string) (*DxDoc, error)                //Latest greatest published revision of document
+	Revisions(id string) ([]int, error)              //Revisions all published revisions of document in ascending order
+	Retire(id string, revision int) error            //Retire mark published revision as retired, it can still be retrieved
+	IsRetired(id string, revision int) (bool, error) //IsRetired is published revision retired
+}
+
+//checkPublish make sure document can be published after revisions which are already published
+func checkPublish(doc *DxDoc, revisions []int) error {
+	if err := validateSchemaID(doc.ID); err != nil {
+		return err
+	}
+
+	if doc.Revision < 1 {
+		return fmt.Errorf("schema %s revision must be positive but get %d", doc.ID, doc.Revision)
+	}
+
+	for _, revision := range revisions {
+		if revision == doc.Revision {
+			return fmt.Errorf("schema %s@%d is already published", doc.ID, doc.Revision)
+		}
+
+		if revision > doc.Revision {
+			return fmt.Errorf("schema %s@%d must be greater than published revision %d",
+				doc.ID, doc.Revision, revision)
+		}
+	}
+
+	return nil
+}
+
+//validateSchemaID make sure ID can be used as directory name
+func validateSchemaID(id string) error {
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+		return fmt.Errorf("schema ID '%s' is not allowed in registry", id)
+	}
+
+	return nil
+}
+
+//MemorySchemaRegistry in-memory SchemaRegistry, mainly for testing
+type MemorySchemaRegistry struct {
+	mutex   sync.RWMutex
+	docs    map[string]map[int]string //docs document XML by ID and revision
+	retired map[string]bool           //retired ID@revision which is retired
+}
+
+//NewMemorySchemaRegistry create empty in-memory registry
+func NewMemorySchemaRegistry() *MemorySchemaRegistry {
+	return &MemorySchemaRegistry{docs: make(map[string]map[int]string), retired: make(map[string]bool)}
+}
+
+//Publish store document XML
+func (registry *MemorySchemaRegistry) Publish(doc *DxDoc) error {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if err := checkPublish(doc, registry.revisions(doc.ID)); err != nil {
+		return err
+	}
+
+	rawXML, err := doc.XML()
+	if err != nil {
+		return err
+	}
+
+	if registry.docs[doc.ID] == nil {
+		registry.docs[doc.ID] = make(map[int]string)
+	}
+
+	registry.docs[doc.ID][doc.Revision] = rawXML
+
+	return nil
+}
+
+//Get pinned revision of document
+func (registry *MemorySchemaRegistry) Get(id string, revision int) (*DxDoc, error) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	rawXML, ok := registry.docs[id][revision]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s@%d", ErrSchemaNotFound, id, revision)
+	}
+
+	return ParseSchemaFromXML(rawXML)
+}
+
+//Latest greatest published revision of document
+func (registry *MemorySchemaRegistry) Latest(id string) (*DxDoc, error) {
+	revisions, _ := registry.Revisions(id)
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrSchemaNotFound, id)
+	}
+
+	return registry.Get(id, revisions[len(revisions)-1])
+}
+
+//Revisions all published revisions of document in ascending order
+func (registry *MemorySchemaRegistry) Revisions(id string) ([]int, error) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	return registry.revisions(id), nil
+}
+
+//Retire mark published revision as retired
+func (registry *MemorySchemaRegistry) Retire(id string, revision int) error {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if _, ok := registry.docs[id][revision]; !ok {
+		return fmt.Errorf("%w: %s@%d", ErrSchemaNotFound, id, revision)
+	}
+
+	registry.retired[fmt.Sprintf("%s@%d", id, revision)] = true
+
+	return nil
+}
+
+//IsRetired is published revision retired
+func (registry *MemorySchemaRegistry) IsRetired(id string, revision int) (bool, error) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	if _, ok := registry.docs[id][revision]; !ok {
+		return false, fmt.Errorf("%w: %s@%d", ErrSchemaNotFound, id, revision)
+	}
+
+	return registry.retired[fmt.Sprintf("%s@%d", id, revision)], nil
+}
+
+func (registry *MemorySchemaRegistry) revisions(id string) []int {
+	var revisions []int
+	for revision := range registry.docs[id] {
+		revisions = append(revisions, revision)
+	}
+
+	sort.Ints(revisions)
+
+	return revisions
+}
+
+//FileSchemaRegistry SchemaRegistry which stores document XML as <root>/<ID>/<revision>.xml,
+//retired revision is marked by empty file <root>/<ID>/<revision>.retired
+type FileSchemaRegistry struct {
+	root  string
+	mutex sync.Mutex
+}
+
+//NewFileSchemaRegistry create registry which stores documents under directory root
+func NewFileSchemaRegistry(root string) *FileSchemaRegistry {
+	return &FileSchemaRegistry{root: root}
+}
+
+//Publish write document XML into file, existing file is never overwritten
+func (registry *FileSchemaRegistry) Publish(doc *DxDoc) error {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	revisions, err := registry.Revisions(doc.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkPublish(doc, revisions); err != nil {
+		return err
+	}
+
+	rawXML, err := doc.XML()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Join(registry.root, doc.ID), 0755); err != nil {
+		return err
+	}
+
+	//XML is written into temporary file first, then linked as <revision>.xml so partially written file
+	//is never published; linking fails if the file already exists
+	file, err := os.CreateTemp(filepath.Join(registry.root, doc.ID), strconv.Itoa(doc.Revision)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(rawXML); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(file.Name(), 0644); err != nil {
+		return err
+	}
+
+	if err := os.Link(file.Name(), registry.filename(doc.ID, doc.Revision)); err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("schema %s@%d is already published", doc.ID, doc.Revision)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+//Get pinned revision of document
+func (registry *FileSchemaRegistry) Get(id string, revision int) (*DxDoc, error) {
+	if err := validateSchemaID(id); err != nil {
+		return nil, err
+	}
+
+	raw, err := os.ReadFile(registry.filename(id, revision))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s@%d", ErrSchemaNotFound, id, revision)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return ParseSchemaFromXML(string(raw))
+}
+
+//Latest greatest published revision of document
+func (registry *FileSchemaRegistry) Latest(id string) (*DxDoc, error) {
+	revisions, err := registry.Revisions(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrSchemaNotFound, id)
+	}
+
+	return registry.Get(id, revisions[len(revisions)-1])
+}
+
+//Revisions all published revisions of document in ascending order
+func (registry *FileSchemaRegistry) Revisions(id string) ([]int, error) {
+	if err := validateSchemaID(id); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(registry.root, id))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var revisions []int
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".xml") {
+			continue
+		}
+
+		revision, err := strconv.Atoi(strings.TrimSuffix(entry.Name(), ".xml"))
+		if err != nil {
+			continue
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	sort.Ints(revisions)
+
+	return revisions, nil
+}
+
+//Retire mark published revision as retired
+func (registry *FileSchemaRegistry) Retire(id string, revision int) error {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if _, err := registry.IsRetired(id, revision); err != nil {
+		return err
+	}
+
+	return os.WriteFile(registry.retiredFilename(id, revision), nil, 0644)
+}
+
+//IsRetired is published revision retired
+func (registry *FileSchemaRegistry) IsRetired(id string, revision int) (bool, error) {
+	if err := validateSchemaID(id); err != nil {
+		return false, err
+	}
+
+	if _, err := os.Stat(registry.filename(id, revision)); os.IsNotExist(err) {
+		return false, fmt.Errorf("%w: %s@%d", ErrSchemaNotFound, id, revision)
+	} else if err != nil {
+		return false, err
+	}
+
+	_, err := os.Stat(registry.retiredFilename(id, revision))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (registry *FileSchemaRegistry) retiredFilename(id string, revision int) string {
+	return filepath.Join(registry.root, id, strconv.Itoa(revision)+".retired")
+}
+
+func (registry *FileSchemaRegistry) filename(id string, revision int) string {
+	return filepath.Join(registry.root, id, strconv.Itoa(revision)+".xml")
+}
@@ -0,0 +1,126 @@
+package gxschema
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func testSchemaRegistry(t *testing.T, registry SchemaRegistry) {
+	rev1 := &DxDoc{Name: "invoice", ID: "inv", Revision: 1, Items: []DxItem{DxStr{Name: "docNo"}}}
+	rev2 := &DxDoc{Name: "invoice", ID: "inv", Revision: 2, Items: []DxItem{DxStr{Name: "docNo"}, DxStr{Name: "remark"}}}
+
+	if _, err := registry.Latest("inv"); !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("Expect ErrSchemaNotFound from empty registry but get %v", err)
+	}
+
+	for _, doc := range []*DxDoc{rev1, rev2} {
+		if err := registry.Publish(doc); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	invalidDocs := []*DxDoc{
+		rev2, //overwrite published revision
+		{Name: "invoice", ID: "inv", Revision: 1, Items: rev1.Items}, //revision goes backward
+		{Name: "invoice", ID: "inv", Revision: 0, Items: rev1.Items},
+		{Name: "invoice", ID: "../inv", Revision: 1, Items: rev1.Items},
+	}
+
+	for _, doc := range invalidDocs {
+		if err := registry.Publish(doc); err == nil {
+			t.Errorf("Expect error occured on publishing %s@%d", doc.ID, doc.Revision)
+		}
+	}
+
+	latest, err := registry.Latest("inv")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if latest.Revision != 2 || len(latest.Items) != 2 {
+		t.Errorf("Expect latest is revision 2 but get revision %d", latest.Revision)
+	}
+
+	pinned, err := registry.Get("inv", 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if pinned.Revision != 1 || len(pinned.Items) != 1 {
+		t.Errorf("Expect pinned revision 1 but get revision %d", pinned.Revision)
+	}
+
+	if _, err := registry.Get("inv", 3); !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("Expect ErrSchemaNotFound for unpublished revision but get %v", err)
+	}
+
+	revisions, err := registry.Revisions("inv")
+	if err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(revisions, []int{1, 2}) {
+		t.Errorf("Expect revisions [1 2] but get %v", revisions)
+	}
+
+	if err := registry.Retire("inv", 1); err != nil {
+		t.Error(err)
+	}
+
+	if retired, err := registry.IsRetired("inv", 1); err != nil || !retired {
+		t.Errorf("Expect revision 1 is retired but get %v, %v", retired, err)
+	}
+
+	if retired, err := registry.IsRetired("inv", 2); err != nil || retired {
+		t.Errorf("Expect revision 2 is not retired but get %v, %v", retired, err)
+	}
+
+	if err := registry.Retire("inv", 3); !errors.Is(err, ErrSchemaNotFound) {
+		t.Errorf("Expect ErrSchemaNotFound on retiring unpublished revision but get %v", err)
+	}
+}
+
+func TestMemorySchemaRegistry(t *testing.T) {
+	testSchemaRegistry(t, NewMemorySchemaRegistry())
+}
+
+func TestFileSchemaRegistry(t *testing.T) {
+	testSchemaRegistry(t, NewFileSchemaRegistry(t.TempDir()))
+}
+
+func TestFileSchemaRegistry_Publish_expectNoPartialFile(t *testing.T) {
+	root := t.TempDir()
+	registry := NewFileSchemaRegistry(root)
+
+	if err := registry.Publish(&DxDoc{Name: "invoice", ID: "inv", Revision: 1, Items: []DxItem{DxStr{Name: "docNo"}}}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	//revision 3 is not listed by Revisions, yet its file can't be created
+	if err := os.Mkdir(filepath.Join(root, "inv", "3.xml"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := registry.Publish(&DxDoc{Name: "invoice", ID: "inv", Revision: 3, Items: []DxItem{DxStr{Name: "docNo"}}}); err == nil {
+		t.Error("Expect error occured on publishing revision whose file can't be created")
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, "inv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	if !reflect.DeepEqual(names, []string{"1.xml", "3.xml"}) {
+		t.Errorf("Expect no temporary file is left but get %v", names)
+	}
+}
@@ -0,0 +1,180 @@
+package gxschema
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//ValidationErrorCode machine readable reason of a violation
+type ValidationErrorCode string
+
+const (
+	CodeMissing    ValidationErrorCode = "missing"    //CodeMissing mandatory value is not found
+	CodeWrongType  ValidationErrorCode = "wrong_type" //CodeWrongType value type not match with definition
+	CodeLength     ValidationErrorCode = "length"     //CodeLength string length not match with definition
+	CodePrecision  ValidationErrorCode = "precision"  //CodePrecision decimal has more digits than precision
+	CodeFormat     ValidationErrorCode = "format"     //CodeFormat string is not in expected format, e.g. date
+	CodeTimezone   ValidationErrorCode = "timezone"   //CodeTimezone value has no UTC offset
+	CodeMin        ValidationErrorCode = "min"        //CodeMin value is less than lower limit
+	CodeMax        ValidationErrorCode = "max"        //CodeMax value is greater than upper limit
+	CodeUndeclared ValidationErrorCode = "undeclared" //CodeUndeclared key is not declared in strict DxDoc or DxSection
+	CodePattern    ValidationErrorCode = "pattern"    //CodePattern string does not match regular expression
+	CodeEnum       ValidationErrorCode = "enum"       //CodeEnum value is not one of declared options
+	CodeMinItems   ValidationErrorCode = "min_items"  //CodeMinItems array has fewer elements than lower limit
+	CodeMaxItems   ValidationErrorCode = "max_items"  //CodeMaxItems array has more elements than upper limit
+	CodeUnique     ValidationErrorCode = "unique"     //CodeUnique array element duplicates an earlier element
+	CodeInvalid    ValidationErrorCode = "invalid"    //CodeInvalid value rejected by item which only implements ValidateData
+)
+
+//ValidationError single violation found while validating input data
+type ValidationError struct {
+	Path     string              //Path location of offending value in JSON Pointer format, e.g. /items/2/qty
+	Item     DxItem              //Item definition which rejects the value, nil for undeclared key
+	Code     ValidationErrorCode //Code machine readable reason
+	Expected interface{}         //Expected expected data type or constraint, e.g. "string", 6
+	Actual   interface{}         //Actual offending value, nil if value is missing
+	Message  string              //Message human readable reason, without field name
+	Line     int                 //Line line number of offending element or attribute in XML data, 0 for other data
+	Column   int                 //Column column number (in bytes) of offending element or attribute in XML data
+}
+
+func (err *ValidationError) Error() string {
+	if err.Line > 0 {
+		return fmt.Sprintf("%s %s (line %d, column %d)", err.Field(), err.Message, err.Line, err.Column)
+	}
+
+	return err.Field() + " " + err.Message
+}
+
+//Field location of offending value in dotted format, e.g. items[2].qty
+func (err *ValidationError) Field() string {
+	var result string
+
+	for _, segment := range strings.Split(strings.TrimPrefix(err.Path, "/"), "/") {
+		segment = strings.Replace(strings.Replace(segment, "~1", "/", -1), "~0", "~", -1)
+
+		if _, indexErr := strconv.Atoi(segment); indexErr == nil {
+			result += "[" + segment + "]"
+		} else if result == "" {
+			result = segment
+		} else {
+			result += "." + segment
+		}
+	}
+
+	return result
+}
+
+//newValidationError create violation of an item
+func newValidationError(item DxItem, path string, code ValidationErrorCode,
+	expected interface{}, actual interface{}, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{Path: path, Item: item, Code: code,
+		Expected: expected, Actual: actual, Message: fmt.Sprintf(format, args...)}
+}
+
+//jsonPointer build JSON Pointer from map key or array index, e.g. jsonPointer("items", 2) is /items/2
+func jsonPointer(segments ...interface{}) string {
+	var result string
+
+	for _, segment := range segments {
+		str := fmt.Sprint(segment)
+		result += "/" + strings.Replace(strings.Replace(str, "~", "~0", -1), "/", "~1", -1)
+	}
+
+	return result
+}
+
+//ValidationReport every violation found while validating input data
+type ValidationReport struct {
+	Errors []*ValidationError
+
+	failFast bool //failFast stop validating at first violation, used by ValidateData
+}
+
+//Error join all violation messages, one message per line
+func (report *ValidationReport) Error() string {
+	messages := make([]string, len(report.Errors))
+	for index, err := range report.Errors {
+		messages[index] = err.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+//Unwrap expose every violation to errors.Is and errors.As
+func (report *ValidationReport) Unwrap() []error {
+	result := make([]error, len(report.Errors))
+	for index, err := range report.Errors {
+		result[index] = err
+	}
+
+	return result
+}
+
+//IsValid is input data free from any violation
+func (report *ValidationReport) IsValid() bool { return len(report.Errors) == 0 }
+
+//Add add a violation into report
+func (report *ValidationReport) Add(err *ValidationError) {
+	report.Errors = append(report.Errors, err)
+}
+
+//addNested add violations found inside a nested item (e.g. DxSection) with its path as prefix
+func (report *ValidationReport) addNested(path string, nested *ValidationReport) {
+	for _, err := range nested.Errors {
+		err.Path = path + err.Path
+		report.Add(err)
+	}
+}
+
+//firstError get first violation, nil if input data is valid
+func (report *ValidationReport) firstError() error {
+	if len(report.Errors) == 0 {
+		return nil
+	}
+
+	return report.Errors[0]
+}
+
+//isDone can validation stop, true when fail-fast report already has a violation
+func (report *ValidationReport) isDone() bool {
+	return report.failFast && len(report.Errors) > 0
+}
+
+//validateFirst run item's CollectErrors with fail-fast report, so validation stops at first violation
+func validateFirst(item ErrorCollector, input map[string]interface{}, name string) error {
+	report := &ValidationReport{failFast: true}
+	item.CollectErrors(input, name, report)
+
+	return report.firstError()
+}
+
+//collectItemErrors add violations of item into report; item which doesn't implement ErrorCollector
+//contributes the error returned by its ValidateData
+func collectItemErrors(item DxItem, input map[string]interface{}, name string, report *ValidationReport) {
+	if collector, ok := item.(ErrorCollector); ok {
+		collector.CollectErrors(input, name, report)
+		return
+	}
+
+	err := item.ValidateData(input, name)
+	if err == nil {
+		return
+	}
+
+	var nestedReport *ValidationReport
+	var validationErr *ValidationError
+
+	switch {
+	case errors.As(err, &nestedReport):
+		for _, nestedErr := range nestedReport.Errors {
+			report.Add(nestedErr)
+		}
+	case errors.As(err, &validationErr):
+		report.Add(validationErr)
+	default:
+		report.Add(newValidationError(item, jsonPointer(name), CodeInvalid, nil, input[name], "%s", err.Error()))
+	}
+}
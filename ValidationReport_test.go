@@ -0,0 +1,114 @@
+package gxschema
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidationError_Field(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "root item", path: "/docNo", want: "docNo"},
+		{name: "array element", path: "/price/1", want: "price[1]"},
+		{name: "nested item", path: "/items/2/qty", want: "items[2].qty"},
+		{name: "nested array element", path: "/items/0/tags/3", want: "items[0].tags[3]"},
+		{name: "escaped key", path: "/a~1b/c~0d", want: "a/b.c~d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &ValidationError{Path: tt.path}
+			if got := err.Field(); got != tt.want {
+				t.Errorf("ValidationError.Field() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidationError_errorsAs(t *testing.T) {
+	qty := DxInt{Name: "qty"}
+	doc := DxDoc{Name: "order", Revision: 1, Items: []DxItem{
+		DxSection{Name: "items", IsArray: true, Items: []DxItem{qty}},
+	}}
+
+	err := doc.ValidateData(map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"qty": 1},
+			map[string]interface{}{"qty": "two"},
+		},
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("expect *ValidationError but get %v", err)
+		return
+	}
+
+	if validationErr.Path != "/items/1/qty" {
+		t.Errorf("expect path /items/1/qty but get %s", validationErr.Path)
+	}
+
+	if validationErr.Code != CodeWrongType {
+		t.Errorf("expect code %s but get %s", CodeWrongType, validationErr.Code)
+	}
+
+	if validationErr.Item != qty {
+		t.Errorf("expect offending item is qty but get %v", validationErr.Item)
+	}
+
+	if validationErr.Expected != "int" || validationErr.Actual != "two" {
+		t.Errorf("expect int vs two but get %v vs %v", validationErr.Expected, validationErr.Actual)
+	}
+
+	if validationErr.Error() != "items[1].qty is not int but string" {
+		t.Errorf("unexpected error message: %s", validationErr.Error())
+	}
+
+	report := doc.ValidateAll(map[string]interface{}{"items": []interface{}{"one"}})
+
+	var reportErr error = report
+	if !errors.As(reportErr, &validationErr) || validationErr.Path != "/items/0" {
+		t.Errorf("expect report expose *ValidationError of /items/0 but get %v", reportErr)
+	}
+}
+
+//countingItem item which always reports a violation and counts how many times it is validated
+type countingItem struct {
+	Name  string
+	count *int
+}
+
+func (item countingItem) GetName() string            { return item.Name }
+func (item countingItem) XML(indentLevel int) string { return "" }
+func (item countingItem) IsValueOptional() bool      { return false }
+func (item countingItem) IsValueArray() bool         { return false }
+
+func (item countingItem) ValidateData(input map[string]interface{}, name string) error {
+	return validateFirst(item, input, name)
+}
+
+func (item countingItem) CollectErrors(input map[string]interface{}, name string, report *ValidationReport) {
+	*item.count++
+	report.Add(&ValidationError{Path: jsonPointer(name), Item: item, Code: CodeFormat, Message: "is rejected"})
+}
+
+func TestDxDoc_ValidateData_failFast(t *testing.T) {
+	count := 0
+	doc := DxDoc{Name: "order", Revision: 1, Items: []DxItem{
+		countingItem{Name: "a", count: &count},
+		countingItem{Name: "b", count: &count},
+		&DxSection{Name: "c", Items: []DxItem{countingItem{Name: "d", count: &count}}},
+	}}
+	input := map[string]interface{}{"a": 1, "b": 2, "c": map[string]interface{}{"d": 3}}
+
+	if err := doc.ValidateData(input); err == nil || count != 1 {
+		t.Errorf("expect ValidateData stops at first violation but %d items are validated: %v", count, err)
+	}
+
+	count = 0
+	if report := doc.ValidateAll(input); len(report.Errors) != 3 || count != 3 {
+		t.Errorf("expect ValidateAll validates every item but get %d violations:\n%s", len(report.Errors), report.Error())
+	}
+}
@@ -0,0 +1,220 @@
+package gxschema
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//xmlPosition line and column (1-based, column counted in bytes) of element or attribute in XML source,
+//zero if position is unknown
+type xmlPosition struct {
+	line   int
+	column int
+}
+
+func (position xmlPosition) String() string {
+	return fmt.Sprintf("line %d, column %d", position.line, position.column)
+}
+
+//attributeError invalid value of XML attribute, so error can be located at the attribute instead of its element
+type attributeError struct {
+	name    xml.Name
+	message string
+}
+
+func (err *attributeError) Error() string {
+	return err.message
+}
+
+//unmarshalXMLNode decode root element of XML source into node, line and column of every element and attribute
+//are recorded so error can be located in source
+func unmarshalXMLNode(rawXML string, node *XMLNode) error {
+	decoder := xml.NewDecoder(strings.NewReader(rawXML))
+
+	for {
+		line, column := decoder.InputPos()
+		offset := decoder.InputOffset()
+
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			node.Line, node.Column, node.offset = line, column, offset
+
+			if err := node.UnmarshalXML(decoder, start); err != nil {
+				return err
+			}
+
+			locateAttributes(rawXML, lineOffsets(rawXML), node)
+
+			return nil
+		}
+	}
+}
+
+//lineOffsets byte offset where every line of source starts
+func lineOffsets(source string) []int {
+	offsets := []int{0}
+
+	for index := 0; index < len(source); index++ {
+		if source[index] == '\n' {
+			offsets = append(offsets, index+1)
+		}
+	}
+
+	return offsets
+}
+
+//sourcePosition convert byte offset of source into line and column
+func sourcePosition(offsets []int, offset int) xmlPosition {
+	line := sort.Search(len(offsets), func(index int) bool { return offsets[index] > offset })
+
+	return xmlPosition{line: line, column: offset - offsets[line-1] + 1}
+}
+
+//locateAttributes find position of every attribute of node and its children by scanning their start tags;
+//attributes are reported by decoder in the order they appear
+func locateAttributes(rawXML string, offsets []int, node *XMLNode) {
+	for index := range node.Nodes {
+		locateAttributes(rawXML, offsets, &node.Nodes[index])
+	}
+
+	node.attributePositions = nil
+
+	index := int(node.offset)
+	if len(node.Attributes) == 0 || index >= len(rawXML) || rawXML[index] != '<' {
+		return
+	}
+
+	isSpace := func(char byte) bool { return char == ' ' || char == '\t' || char == '\r' || char == '\n' }
+
+	//skip tag name
+	for index++; index < len(rawXML) && !isSpace(rawXML[index]) && rawXML[index] != '>'; index++ {
+	}
+
+	var positions []xmlPosition
+
+	for len(positions) < len(node.Attributes) {
+		for index < len(rawXML) && isSpace(rawXML[index]) {
+			index++
+		}
+
+		if index >= len(rawXML) || rawXML[index] == '>' || rawXML[index] == '/' {
+			break
+		}
+
+		positions = append(positions, sourcePosition(offsets, index))
+
+		//skip name and equal sign, then quoted value
+		quote := strings.IndexAny(rawXML[index:], `"'`)
+		if quote < 0 {
+			break
+		}
+
+		index += quote
+		end := strings.IndexByte(rawXML[index+1:], rawXML[index])
+		if end < 0 {
+			break
+		}
+
+		index += end + 2
+	}
+
+	if len(positions) == len(node.Attributes) {
+		node.attributePositions = positions
+	}
+}
+
+//position line and column of element start tag
+func (e *XMLNode) position() xmlPosition {
+	return xmlPosition{line: e.Line, column: e.Column}
+}
+
+//attributePosition line and column of attribute by its index, fallback to element position if unknown
+func (e *XMLNode) attributePosition(index int) xmlPosition {
+	if index < len(e.attributePositions) {
+		return e.attributePositions[index]
+	}
+
+	return e.position()
+}
+
+//location describe xmlPath with line and column of node in XML source, e.g. dxdoc>dxint(0) (line 3, column 2);
+//attribute position is used instead when err is caused by attribute value
+func (e *XMLNode) location(xmlPath string, err error) string {
+	position := e.position()
+
+	var attrErr *attributeError
+	if errors.As(err, &attrErr) {
+		for index, attribute := range e.Attributes {
+			if attribute.Name == attrErr.name {
+				position = e.attributePosition(index)
+			}
+		}
+	}
+
+	if position.line == 0 {
+		return xmlPath
+	}
+
+	return fmt.Sprintf("%s (%s)", xmlPath, position)
+}
+
+//declarationError error caused by item, type or removal declared directly under dxdoc which is found after every
+//node is walked, e.g. while extending base document; key is tag and name of declaration, e.g. dxtype:address
+type declarationError struct {
+	key     string
+	message string
+}
+
+func (err *declarationError) Error() string {
+	return err.message
+}
+
+//schemaLocations location of nodes walked from schema XML, so error found after every node is walked
+//(extending base document, resolving types) can still be located
+type schemaLocations struct {
+	sections     map[*DxSection]string //sections location of dxsection which refers to DxType
+	declarations map[string]string     //declarations location of node directly under dxdoc by tag and name
+}
+
+func newSchemaLocations() *schemaLocations {
+	return &schemaLocations{sections: make(map[*DxSection]string), declarations: make(map[string]string)}
+}
+
+//addSection record location of every typed dxsection in item walked from node
+func (locations *schemaLocations) addSection(item DxItem, node *XMLNode, xmlPath string) {
+	section, ok := item.(*DxSection)
+	if !ok {
+		return
+	}
+
+	if section.TypeName != "" {
+		locations.sections[section] = node.location(xmlPath, nil)
+		return
+	}
+
+	for index := range section.Items {
+		if index < len(node.Nodes) {
+			locations.addSection(section.Items[index], &node.Nodes[index],
+				fmt.Sprintf("%s>%s(%d)", xmlPath, node.Nodes[index].XMLName.Local, index))
+		}
+	}
+}
+
+//describe location of declaration which causes err, empty if unknown
+func (locations *schemaLocations) describe(err error) string {
+	var declarationErr *declarationError
+	if errors.As(err, &declarationErr) {
+		if location, ok := locations.declarations[declarationErr.key]; ok {
+			return " at path " + location
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,495 @@
+package gxschema
+
+import (
+	"fmt"
+	"reflect"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+//XSD generate document definition into W3C XML schema 1.0 (XSD) format;
+//child elements are declared as xs:sequence, so data XML shall list its elements in the same order as
+//document definition. Document or section which is not strict also accepts undeclared attributes and
+//namespace qualified elements (XSD 1.0 wildcard can't overlap declared elements)
+func (doc DxDoc) XSD() (string, error) {
+	result := "<?xml version=\"1.0\"?>\n" +
+		"<xs:schema xmlns:xs=\"http://www.w3.org/2001/XMLSchema\">\n" +
+		"\t<xs:element name=\"" + escapeXMLAttribute(doc.Name) + "\">\n"
+
+	//schema reference of root element is accepted by ValidateAuto
+	complexType, err := xsdComplexType(doc.Items, doc.IsStrict, []string{"<xs:attribute name=\"dxschema\" type=\"xs:string\"/>"}, 2)
+	if err != nil {
+		return "", err
+	}
+
+	return result + complexType + "\n\t</xs:element>\n</xs:schema>", nil
+}
+
+//xsdComplexType generate xs:complexType which contains all items as xs:sequence followed by attributes;
+//wildcard of undeclared elements and attributes is added when strict is false
+func xsdComplexType(items []DxItem, strict bool, attributes []string, indentLevel int) (string, error) {
+	indent := xsdIndent(indentLevel)
+
+	result := indent + "<xs:complexType>\n" + indent + "\t<xs:sequence>"
+
+	for _, item := range items {
+		element, err := xsdElement(item, indentLevel+2)
+		if err != nil {
+			return "", err
+		}
+
+		result += "\n" + element
+	}
+
+	if !strict {
+		result += "\n" + indent + "\t\t<xs:any namespace=\"##other\" processContents=\"lax\" minOccurs=\"0\" maxOccurs=\"unbounded\"/>"
+		attributes = append(attributes, "<xs:anyAttribute processContents=\"lax\"/>")
+	}
+
+	result += "\n" + indent + "\t</xs:sequence>"
+
+	for _, attribute := range attributes {
+		result += "\n" + indent + "\t" + attribute
+	}
+
+	return result + "\n" + indent + "</xs:complexType>", nil
+}
+
+//xsdElement generate xs:element of a document item
+func xsdElement(item DxItem, indentLevel int) (string, error) {
+	indent := xsdIndent(indentLevel)
+
+	result := indent + "<xs:element name=\"" + escapeXMLAttribute(item.GetName()) + "\""
+
+	//uniqueItems and uniqueBy are not exported
+	spec := itemArraySpec(item)
+
+	if item.IsValueOptional() {
+		result += " minOccurs=\"0\""
+	} else if item.IsValueArray() && spec.minItems > 1 {
+		result += fmt.Sprintf(" minOccurs=\"%d\"", spec.minItems)
+	}
+
+	if item.IsValueArray() {
+		if spec.maxItems > 0 {
+			result += fmt.Sprintf(" maxOccurs=\"%d\"", spec.maxItems)
+		} else {
+			result += " maxOccurs=\"unbounded\""
+		}
+	}
+
+	switch tmp := dereferenceItem(item).(type) {
+	case DxStr:
+		facets, err := xsdStrFacets(tmp)
+		if err != nil {
+			return "", fmt.Errorf("'%s' %s", item.GetName(), err.Error())
+		}
+
+		if len(facets) == 0 {
+			return result + " type=\"xs:string\"/>", nil
+		}
+
+		return result + ">\n" + xsdSimpleType("xs:string", facets, indentLevel+1) +
+			"\n" + indent + "</xs:element>", nil
+	case DxInt:
+		facets := xsdRangeFacets(tmp.numericRange())
+		if len(facets) == 0 {
+			return result + " type=\"xs:integer\"/>", nil
+		}
+
+		return result + ">\n" + xsdSimpleType("xs:integer", facets, indentLevel+1) +
+			"\n" + indent + "</xs:element>", nil
+	case DxDecimal:
+		facets := append([]string{fmt.Sprintf("<xs:fractionDigits value=\"%d\"/>", tmp.Precision)},
+			xsdRangeFacets(tmp.numericRange())...)
+
+		return result + ">\n" + xsdSimpleType("xs:decimal", facets, indentLevel+1) +
+			"\n" + indent + "</xs:element>", nil
+	case DxBool:
+		return result + " type=\"xs:boolean\"/>", nil
+	case DxDate:
+		return result + xsdTemporalType(tmp.temporal(), indentLevel), nil
+	case DxTime:
+		return result + xsdTemporalType(tmp.temporal(), indentLevel), nil
+	case DxDateTime:
+		return result + xsdTemporalType(tmp.temporal(), indentLevel), nil
+	case DxFile:
+		complexType, _ := xsdComplexType([]DxItem{DxStr{Name: "filename"}, DxStr{Name: "filepath"}}, true, nil,
+			indentLevel+1)
+
+		return result + ">\n" + complexType + "\n" + indent + "</xs:element>", nil
+	case DxEnum:
+		//case insensitive option has no XSD equivalent, hence declared as plain string
+		if tmp.IgnoreCase {
+			return result + " type=\"xs:string\"/>", nil
+		}
+
+		var facets []string
+		for _, option := range tmp.Options {
+			facets = append(facets, "<xs:enumeration value=\""+escapeXMLAttribute(option.Value)+"\"/>")
+		}
+
+		return result + ">\n" + xsdSimpleType("xs:string", facets, indentLevel+1) +
+			"\n" + indent + "</xs:element>", nil
+	case DxSection:
+		complexType, err := xsdComplexType(tmp.Items, tmp.IsStrict, nil, indentLevel+1)
+		if err != nil {
+			return "", err
+		}
+
+		return result + ">\n" + complexType + "\n" + indent + "</xs:element>", nil
+	}
+
+	if custom, ok := dereferenceItem(item).(XSDItem); ok {
+		base, facets := custom.XSDType()
+		if len(facets) == 0 {
+			return result + " type=\"" + escapeXMLAttribute(base) + "\"/>", nil
+		}
+
+		return result + ">\n" + xsdSimpleType(escapeXMLAttribute(base), facets, indentLevel+1) +
+			"\n" + indent + "</xs:element>", nil
+	}
+
+	return "", fmt.Errorf("'%s' has no XSD equivalent, unsupported item type %s",
+		item.GetName(), reflect.TypeOf(item))
+}
+
+//xsdTemporalType generate remaining element declaration of dxdate, dxtime or dxdatetime;
+//custom format has no XSD equivalent, hence declared as xs:string.
+//xs:time and xs:dateTime reject value without second (e.g. 15:04) which is accepted, so dxtime and dxdatetime
+//are declared as xs:string restricted by pattern of accepted layouts; min and max cannot be expressed on xs:string
+func xsdTemporalType(spec temporalSpec, indentLevel int) string {
+	if spec.format != "" {
+		return " type=\"xs:string\"/>"
+	}
+
+	if spec.kind != temporalDate {
+		return ">\n" + xsdSimpleType("xs:string", []string{"<xs:pattern value=\"" + spec.pattern() + "\"/>"},
+			indentLevel+1) + "\n" + xsdIndent(indentLevel) + "</xs:element>"
+	}
+
+	var facets []string
+
+	if !spec.min.IsZero() {
+		facets = append(facets, "<xs:minInclusive value=\""+spec.min.Format(temporalCanonicalLayouts[temporalDate])+"\"/>")
+	}
+
+	if !spec.max.IsZero() {
+		facets = append(facets, "<xs:maxInclusive value=\""+spec.max.Format(temporalCanonicalLayouts[temporalDate])+"\"/>")
+	}
+
+	if len(facets) == 0 {
+		return " type=\"xs:date\"/>"
+	}
+
+	return ">\n" + xsdSimpleType("xs:date", facets, indentLevel+1) + "\n" + xsdIndent(indentLevel) + "</xs:element>"
+}
+
+//xsdStrFacets generate length and pattern facets of dxstr;
+//XSD measures length in unicode code points regardless of LenUnit
+func xsdStrFacets(item DxStr) ([]string, error) {
+	var facets []string
+
+	min, hasMin, max, hasMax := item.lengthRange()
+
+	if hasMin && hasMax && min == max {
+		facets = append(facets, fmt.Sprintf("<xs:length value=\"%d\"/>", min))
+	} else {
+		if hasMin {
+			facets = append(facets, fmt.Sprintf("<xs:minLength value=\"%d\"/>", min))
+		}
+
+		if hasMax {
+			facets = append(facets, fmt.Sprintf("<xs:maxLength value=\"%d\"/>", max))
+		}
+	}
+
+	if item.Pattern != nil {
+		pattern, err := xsdPattern(item.Pattern.String())
+		if err != nil {
+			return nil, err
+		}
+
+		facets = append(facets, "<xs:pattern value=\""+escapeXMLAttribute(pattern)+"\"/>")
+	}
+
+	return facets, nil
+}
+
+//xsdRangeFacets generate min and max facets of dxint and dxdecimal
+func xsdRangeFacets(spec numericRange) []string {
+	var facets []string
+
+	if spec.enableMin {
+		if spec.exclusiveMin {
+			facets = append(facets, "<xs:minExclusive value=\""+spec.min.String()+"\"/>")
+		} else {
+			facets = append(facets, "<xs:minInclusive value=\""+spec.min.String()+"\"/>")
+		}
+	}
+
+	if spec.enableMax {
+		if spec.exclusiveMax {
+			facets = append(facets, "<xs:maxExclusive value=\""+spec.max.String()+"\"/>")
+		} else {
+			facets = append(facets, "<xs:maxInclusive value=\""+spec.max.String()+"\"/>")
+		}
+	}
+
+	return facets
+}
+
+//xsdPattern convert GO regular expression into XSD pattern;
+//XSD pattern always match whole value, hence unanchored side is padded with [\s\S]*.
+//Construct which XSD can't express (e.g. word boundary, anchor in the middle) is reported as error
+func xsdPattern(expr string) (string, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return "", fmt.Errorf("pattern %s is invalid: %s", expr, err.Error())
+	}
+
+	subs := []*syntax.Regexp{re}
+	if re.Op == syntax.OpConcat {
+		subs = re.Sub
+	}
+
+	prefix, suffix := `[\s\S]*`, `[\s\S]*`
+
+	if len(subs) > 0 && subs[0].Op == syntax.OpBeginText {
+		subs = subs[1:]
+		prefix = ""
+	}
+
+	if len(subs) > 0 && subs[len(subs)-1].Op == syntax.OpEndText {
+		subs = subs[:len(subs)-1]
+		suffix = ""
+	}
+
+	result, err := xsdConcat(subs)
+	if err != nil {
+		return "", fmt.Errorf("pattern %s has no XSD equivalent: %s", expr, err.Error())
+	}
+
+	return prefix + "(" + result + ")" + suffix, nil
+}
+
+//xsdRegexp convert parsed GO regular expression into XSD regular expression
+func xsdRegexp(re *syntax.Regexp) (string, error) {
+	switch re.Op {
+	case syntax.OpEmptyMatch:
+		return "", nil
+	case syntax.OpLiteral:
+		var result string
+
+		for _, r := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 {
+				//case insensitive letter is expanded into character class
+				folds := []rune{r, r}
+				for fold := unicode.SimpleFold(r); fold != r; fold = unicode.SimpleFold(fold) {
+					folds = append(folds, fold, fold)
+				}
+
+				if len(folds) > 2 {
+					class, err := xsdCharClass(folds)
+					if err != nil {
+						return "", err
+					}
+
+					result += class
+					continue
+				}
+			}
+
+			char, err := xsdChar(r)
+			if err != nil {
+				return "", err
+			}
+
+			result += char
+		}
+
+		return result, nil
+	case syntax.OpCharClass:
+		return xsdCharClass(re.Rune)
+	case syntax.OpAnyCharNotNL:
+		return `[^\n]`, nil
+	case syntax.OpAnyChar:
+		return `[\s\S]`, nil
+	case syntax.OpCapture:
+		sub, err := xsdRegexp(re.Sub[0])
+		return "(" + sub + ")", err
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		//non greedy repetition matches same values since XSD pattern always match whole value
+		sub, err := xsdAtom(re.Sub[0])
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case re.Op == syntax.OpStar:
+			return sub + "*", nil
+		case re.Op == syntax.OpPlus:
+			return sub + "+", nil
+		case re.Op == syntax.OpQuest:
+			return sub + "?", nil
+		case re.Max < 0:
+			return fmt.Sprintf("%s{%d,}", sub, re.Min), nil
+		case re.Min == re.Max:
+			return fmt.Sprintf("%s{%d}", sub, re.Min), nil
+		}
+
+		return fmt.Sprintf("%s{%d,%d}", sub, re.Min, re.Max), nil
+	case syntax.OpConcat:
+		return xsdConcat(re.Sub)
+	case syntax.OpAlternate:
+		var branches []string
+
+		for _, sub := range re.Sub {
+			branch, err := xsdRegexp(sub)
+			if err != nil {
+				return "", err
+			}
+
+			branches = append(branches, branch)
+		}
+
+		return strings.Join(branches, "|"), nil
+	}
+
+	return "", fmt.Errorf("%s is not supported", re.String())
+}
+
+//xsdConcat convert sequence of parsed GO regular expressions, alternation is grouped
+func xsdConcat(subs []*syntax.Regexp) (string, error) {
+	var result string
+
+	for _, sub := range subs {
+		part, err := xsdRegexp(sub)
+		if err != nil {
+			return "", err
+		}
+
+		if sub.Op == syntax.OpAlternate {
+			part = "(" + part + ")"
+		}
+
+		result += part
+	}
+
+	return result, nil
+}
+
+//xsdAtom convert parsed GO regular expression which is repeated, grouped unless it is single character or group
+func xsdAtom(re *syntax.Regexp) (string, error) {
+	result, err := xsdRegexp(re)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case re.Op == syntax.OpLiteral && len(re.Rune) == 1, re.Op == syntax.OpCharClass, re.Op == syntax.OpAnyChar,
+		re.Op == syntax.OpAnyCharNotNL, re.Op == syntax.OpCapture:
+		return result, nil
+	}
+
+	return "(" + result + ")", nil
+}
+
+//xmlCharRanges characters allowed in XML document, XSD pattern can't refer to other characters
+var xmlCharRanges = []rune{0x9, 0xA, 0xD, 0xD, 0x20, 0xD7FF, 0xE000, 0xFFFD, 0x10000, unicode.MaxRune}
+
+//xsdCharClass convert character ranges (pairs of lowest and highest character) into XSD character class;
+//class which covers both ends of unicode is declared as negation of its gaps
+func xsdCharClass(ranges []rune) (string, error) {
+	negate := len(ranges) > 0 && ranges[0] == 0 && ranges[len(ranges)-1] == unicode.MaxRune
+
+	if negate {
+		var gaps []rune
+		for index := 1; index+1 < len(ranges); index += 2 {
+			gaps = append(gaps, ranges[index]+1, ranges[index+1]-1)
+		}
+
+		ranges = gaps
+	}
+
+	var result string
+
+	for index := 0; index+1 < len(ranges); index += 2 {
+		for xmlIndex := 0; xmlIndex < len(xmlCharRanges); xmlIndex += 2 {
+			lo, hi := ranges[index], ranges[index+1]
+			if lo < xmlCharRanges[xmlIndex] {
+				lo = xmlCharRanges[xmlIndex]
+			}
+
+			if hi > xmlCharRanges[xmlIndex+1] {
+				hi = xmlCharRanges[xmlIndex+1]
+			}
+
+			if lo > hi {
+				continue
+			}
+
+			loChar, _ := xsdChar(lo)
+			result += loChar
+
+			if hi > lo {
+				hiChar, _ := xsdChar(hi)
+				result += "-" + hiChar
+			}
+		}
+	}
+
+	switch {
+	case negate && result == "":
+		return `[\s\S]`, nil
+	case negate:
+		return "[^" + result + "]", nil
+	case result == "":
+		return "", fmt.Errorf("character class has no XML character")
+	}
+
+	return "[" + result + "]", nil
+}
+
+//xsdChar escape single character of XSD regular expression
+func xsdChar(r rune) (string, error) {
+	switch r {
+	case '\n':
+		return `\n`, nil
+	case '\r':
+		return `\r`, nil
+	case '\t':
+		return `\t`, nil
+	}
+
+	for index := 0; index+1 < len(xmlCharRanges); index += 2 {
+		if r >= xmlCharRanges[index] && r <= xmlCharRanges[index+1] {
+			if strings.ContainsRune(`\|.?*+(){}-[]^`, r) {
+				return `\` + string(r), nil
+			}
+
+			return string(r), nil
+		}
+	}
+
+	return "", fmt.Errorf("character %U is not allowed in XML", r)
+}
+
+//xsdSimpleType generate xs:simpleType restricted by facets
+func xsdSimpleType(base string, facets []string, indentLevel int) string {
+	indent := xsdIndent(indentLevel)
+
+	result := indent + "<xs:simpleType>\n" +
+		indent + "\t<xs:restriction base=\"" + base + "\">"
+
+	for _, facet := range facets {
+		result += "\n" + indent + "\t\t" + facet
+	}
+
+	return result + "\n" + indent + "\t</xs:restriction>\n" + indent + "</xs:simpleType>"
+}
+
+func xsdIndent(indentLevel int) string {
+	return strings.Repeat("\t", indentLevel)
+}
@@ -0,0 +1,337 @@
+package gxschema
+
+import (
+	"encoding/xml"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestDxDoc_XSD(t *testing.T) {
+	doc := DxDoc{
+		Name:     "invoice",
+		Revision: 3,
+		ID:       "733bee1b-f79a-4cb7-b675-842317b994b5",
+		Items: []DxItem{
+			DxStr{Name: "docNo", EnableLenLimit: true, LenLimit: 6},
+			&DxDecimal{Name: "total", Precision: 2},
+			DxBool{Name: "isPaid", IsOptional: true},
+			DxDate{Name: "issueDate", Min: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)},
+			DxFile{Name: "attachment", IsOptional: true, IsArray: true},
+			DxSection{Name: "items", IsArray: true, Items: []DxItem{
+				DxStr{Name: "description"},
+				DxInt{Name: "qty"},
+			}},
+		},
+	}
+
+	xsdStr, xsdErr := doc.XSD()
+	if xsdErr != nil {
+		t.Error(xsdErr)
+		return
+	}
+
+	expectedXSD := `<?xml version="1.0"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+	<xs:element name="invoice">
+		<xs:complexType>
+			<xs:sequence>
+				<xs:element name="docNo">
+					<xs:simpleType>
+						<xs:restriction base="xs:string">
+							<xs:length value="6"/>
+						</xs:restriction>
+					</xs:simpleType>
+				</xs:element>
+				<xs:element name="total">
+					<xs:simpleType>
+						<xs:restriction base="xs:decimal">
+							<xs:fractionDigits value="2"/>
+						</xs:restriction>
+					</xs:simpleType>
+				</xs:element>
+				<xs:element name="isPaid" minOccurs="0" type="xs:boolean"/>
+				<xs:element name="issueDate">
+					<xs:simpleType>
+						<xs:restriction base="xs:date">
+							<xs:minInclusive value="2018-01-01"/>
+						</xs:restriction>
+					</xs:simpleType>
+				</xs:element>
+				<xs:element name="attachment" minOccurs="0" maxOccurs="unbounded">
+					<xs:complexType>
+						<xs:sequence>
+							<xs:element name="filename" type="xs:string"/>
+							<xs:element name="filepath" type="xs:string"/>
+						</xs:sequence>
+					</xs:complexType>
+				</xs:element>
+				<xs:element name="items" maxOccurs="unbounded">
+					<xs:complexType>
+						<xs:sequence>
+							<xs:element name="description" type="xs:string"/>
+							<xs:element name="qty" type="xs:integer"/>
+							<xs:any namespace="##other" processContents="lax" minOccurs="0" maxOccurs="unbounded"/>
+						</xs:sequence>
+						<xs:anyAttribute processContents="lax"/>
+					</xs:complexType>
+				</xs:element>
+				<xs:any namespace="##other" processContents="lax" minOccurs="0" maxOccurs="unbounded"/>
+			</xs:sequence>
+			<xs:attribute name="dxschema" type="xs:string"/>
+			<xs:anyAttribute processContents="lax"/>
+		</xs:complexType>
+	</xs:element>
+</xs:schema>`
+
+	if strings.Compare(xsdStr, expectedXSD) != 0 {
+		t.Errorf("XSD output not tally with [output]: \n%s\n\n[expected]:\n%s", xsdStr, expectedXSD)
+	}
+
+	var n XMLNode
+	if err := xml.Unmarshal([]byte(xsdStr), &n); err != nil {
+		t.Errorf("XSD output is not well formed XML: %s", err.Error())
+	}
+}
+
+func TestDxDoc_XSD_timezone(t *testing.T) {
+	doc := DxDoc{Name: "log", Revision: 1, ID: "1", Items: []DxItem{
+		DxDateTime{Name: "createdAt", RequireTimezone: true},
+		DxTime{Name: "start", Format: "3:04PM"},
+	}}
+
+	xsdStr, xsdErr := doc.XSD()
+	if xsdErr != nil {
+		t.Error(xsdErr)
+		return
+	}
+
+	if !strings.Contains(xsdStr, `<xs:restriction base="xs:string">`) ||
+		!strings.Contains(xsdStr, `<xs:pattern value="\d{4}-\d{2}-\d{2}T\d{1,2}:\d{2}(:\d{2}([.,]\d+)?)?(Z|[+\-]\d{2}:\d{2})"/>`) {
+		t.Errorf("expect createdAt declared as xs:string with timezone pattern:\n%s", xsdStr)
+	}
+
+	if !strings.Contains(xsdStr, `<xs:element name="start" type="xs:string"/>`) {
+		t.Errorf("expect custom formatted time declared as xs:string:\n%s", xsdStr)
+	}
+}
+
+func TestDxDoc_XSD_temporal(t *testing.T) {
+	doc := DxDoc{Name: "shift", Revision: 1, ID: "1", Items: []DxItem{
+		DxTime{Name: "startTime"},
+		DxDateTime{Name: "clockIn"},
+	}}
+
+	xsdStr, xsdErr := doc.XSD()
+	if xsdErr != nil {
+		t.Error(xsdErr)
+		return
+	}
+
+	if strings.Contains(xsdStr, "xs:time") || strings.Contains(xsdStr, "xs:dateTime") {
+		t.Errorf("expect xs:time and xs:dateTime are not used since they reject value without second:\n%s", xsdStr)
+	}
+
+	//XSD pattern is anchored implicitly
+	testCases := []struct {
+		pattern string
+		value   string
+	}{
+		{`\d{1,2}:\d{2}(:\d{2}([.,]\d+)?)?(Z|[+\-]\d{2}:\d{2})?`, "15:04"},
+		{`\d{4}-\d{2}-\d{2}T\d{1,2}:\d{2}(:\d{2}([.,]\d+)?)?(Z|[+\-]\d{2}:\d{2})?`, "2006-01-02T15:04"},
+	}
+
+	for _, testCase := range testCases {
+		if !strings.Contains(xsdStr, `<xs:pattern value="`+testCase.pattern+`"/>`) {
+			t.Errorf("expect pattern %s is declared:\n%s", testCase.pattern, xsdStr)
+		}
+
+		if !regexp.MustCompile("^" + testCase.pattern + "$").MatchString(testCase.value) {
+			t.Errorf("expect pattern %s matches %s", testCase.pattern, testCase.value)
+		}
+	}
+
+	data := `<shift><clockIn>2006-01-02T15:04</clockIn><startTime>15:04</startTime></shift>`
+	if err := ValidateDataFromXML(data, &doc); err != nil {
+		t.Errorf("expect data matching XSD pattern is valid: %s", err.Error())
+	}
+}
+
+func TestDxDoc_XSD_strLength(t *testing.T) {
+	doc := DxDoc{Name: "customer", Revision: 1, ID: "1", Items: []DxItem{
+		DxStr{Name: "surname", EnableMinLen: true, MinLen: 1, EnableMaxLen: true, MaxLen: 50},
+		DxStr{Name: "code", EnableLenLimit: true, LenLimit: 6, EnableMaxLen: true, MaxLen: 10},
+	}}
+
+	xsdStr, xsdErr := doc.XSD()
+	if xsdErr != nil {
+		t.Error(xsdErr)
+		return
+	}
+
+	if !strings.Contains(xsdStr, "<xs:minLength value=\"1\"/>\n\t\t\t\t\t\t\t<xs:maxLength value=\"50\"/>") {
+		t.Errorf("expect surname declared with minLength and maxLength:\n%s", xsdStr)
+	}
+
+	if !strings.Contains(xsdStr, `<xs:length value="6"/>`) || strings.Contains(xsdStr, `<xs:maxLength value="10"/>`) {
+		t.Errorf("expect code declared with exact length only:\n%s", xsdStr)
+	}
+}
+
+func Test_xsdPattern(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{expr: `^ODR\d{4}$`, want: `(ODR[0-9]{4})`},
+		{expr: `ODR`, want: `[\s\S]*(ODR)[\s\S]*`},
+		{expr: `USD\$`, want: `[\s\S]*(USD$)[\s\S]*`},
+		{expr: `\Aa.c\z`, want: `(a[^\n]c)`},
+		{expr: `^(?i)ab$`, want: `([Aa][Bb])`},
+		{expr: `^(?:ab|cd)+?[^-\]]\.x$`, want: `((ab|cd)+[^\-\]]\.x)`},
+		{expr: `^\w\s$`, want: `([0-9A-Z_a-z][\t-\n\r ])`},
+	}
+
+	for _, tt := range tests {
+		got, err := xsdPattern(tt.expr)
+		if err != nil {
+			t.Errorf("xsdPattern(%s) returns error: %s", tt.expr, err.Error())
+		} else if got != tt.want {
+			t.Errorf("xsdPattern(%s) = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{`\bODR\b`, `a^b`, `(?m)^ODR$`, `a$|b`, "\\x00"} {
+		if got, err := xsdPattern(expr); err == nil {
+			t.Errorf("expect xsdPattern(%s) returns error but get %s", expr, got)
+		}
+	}
+
+	doc := DxDoc{Name: "order", Revision: 1, ID: "1", Items: []DxItem{
+		DxStr{Name: "orderNo", Pattern: regexp.MustCompile(`^ODR<\d{4}$`)},
+	}}
+
+	xsdStr, xsdErr := doc.XSD()
+	if xsdErr != nil {
+		t.Error(xsdErr)
+		return
+	}
+
+	if !strings.Contains(xsdStr, `<xs:pattern value="(ODR&lt;[0-9]{4})"/>`) {
+		t.Errorf("expect orderNo declared with escaped pattern:\n%s", xsdStr)
+	}
+}
+
+func TestDxDoc_XSD_enum(t *testing.T) {
+	doc := DxDoc{Name: "invoice", Revision: 1, ID: "1", Items: []DxItem{
+		DxEnum{Name: "status", Options: []DxOption{{Value: "draft"}, {Value: "void"}}},
+		DxEnum{Name: "tags", IsArray: true, IgnoreCase: true, Options: []DxOption{{Value: "urgent"}}},
+	}}
+
+	xsdStr, xsdErr := doc.XSD()
+	if xsdErr != nil {
+		t.Error(xsdErr)
+		return
+	}
+
+	if !strings.Contains(xsdStr, "<xs:enumeration value=\"draft\"/>\n\t\t\t\t\t\t\t<xs:enumeration value=\"void\"/>") {
+		t.Errorf("expect status declared with enumeration:\n%s", xsdStr)
+	}
+
+	if !strings.Contains(xsdStr, `<xs:element name="tags" maxOccurs="unbounded" type="xs:string"/>`) {
+		t.Errorf("expect case insensitive enum declared as xs:string:\n%s", xsdStr)
+	}
+}
+
+func TestDxDoc_XSD_numericRange(t *testing.T) {
+	doc := DxDoc{Name: "order", Revision: 1, ID: "1", Items: []DxItem{
+		DxInt{Name: "qty", EnableMin: true, Min: decimal.New(0, 0), ExclusiveMin: true},
+		DxDecimal{Name: "discount", Precision: 2, EnableMax: true, Max: decimal.New(100, 0)},
+	}}
+
+	xsdStr, xsdErr := doc.XSD()
+	if xsdErr != nil {
+		t.Error(xsdErr)
+		return
+	}
+
+	if !strings.Contains(xsdStr, "<xs:restriction base=\"xs:integer\">\n\t\t\t\t\t\t\t<xs:minExclusive value=\"0\"/>") {
+		t.Errorf("expect qty declared with minExclusive:\n%s", xsdStr)
+	}
+
+	if !strings.Contains(xsdStr, "<xs:fractionDigits value=\"2\"/>\n\t\t\t\t\t\t\t<xs:maxInclusive value=\"100\"/>") {
+		t.Errorf("expect discount declared with maxInclusive:\n%s", xsdStr)
+	}
+}
+
+func TestDxDoc_XSD_arrayConstraints(t *testing.T) {
+	doc := DxDoc{Name: "order", Revision: 1, ID: "1", Items: []DxItem{
+		DxStr{Name: "tags", IsArray: true, IsOptional: true, MinItems: 2, MaxItems: 5},
+		DxInt{Name: "qty", IsArray: true, MinItems: 2},
+	}}
+
+	xsdStr, xsdErr := doc.XSD()
+	if xsdErr != nil {
+		t.Error(xsdErr)
+		return
+	}
+
+	if !strings.Contains(xsdStr, `<xs:element name="tags" minOccurs="0" maxOccurs="5" type="xs:string"/>`) {
+		t.Errorf("expect tags declared with maxOccurs 5:\n%s", xsdStr)
+	}
+
+	if !strings.Contains(xsdStr, `<xs:element name="qty" minOccurs="2" maxOccurs="unbounded" type="xs:integer"/>`) {
+		t.Errorf("expect qty declared with minOccurs 2:\n%s", xsdStr)
+	}
+}
+
+func TestDxDoc_XSD_wildcard(t *testing.T) {
+	items := []DxItem{
+		DxStr{Name: "docNo"},
+		DxInt{Name: "qty", IsArray: true},
+		&DxSection{Name: "customer", IsStrict: true, Items: []DxItem{DxStr{Name: "name"}}},
+	}
+
+	tests := []struct {
+		name     string
+		strict   bool
+		wildcard int
+	}{
+		{name: "not strict", strict: false, wildcard: 1},
+		{name: "strict", strict: true, wildcard: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := DxDoc{Name: "order", Revision: 1, ID: "1", IsStrict: tt.strict, Items: items}
+
+			xsdStr, xsdErr := doc.XSD()
+			if xsdErr != nil {
+				t.Error(xsdErr)
+				return
+			}
+
+			//XSD 1.0 only allows single occurrence of element in xs:all
+			if strings.Contains(xsdStr, "xs:all") || strings.Count(xsdStr, "<xs:sequence>") != 2 {
+				t.Errorf("expect every complex type declares its elements in xs:sequence:\n%s", xsdStr)
+			}
+
+			//strict customer section never accepts undeclared element
+			if count := strings.Count(xsdStr, `<xs:any namespace="##other" processContents="lax"`); count != tt.wildcard {
+				t.Errorf("expect %d element wildcard but get %d:\n%s", tt.wildcard, count, xsdStr)
+			}
+
+			if count := strings.Count(xsdStr, `<xs:anyAttribute processContents="lax"/>`); count != tt.wildcard {
+				t.Errorf("expect %d attribute wildcard but get %d:\n%s", tt.wildcard, count, xsdStr)
+			}
+
+			//schema reference accepted by ValidateAuto is declared even by strict document
+			if strings.Count(xsdStr, `<xs:attribute name="dxschema" type="xs:string"/>`) != 1 {
+				t.Errorf("expect dxschema attribute declared on root element:\n%s", xsdStr)
+			}
+		})
+	}
+}